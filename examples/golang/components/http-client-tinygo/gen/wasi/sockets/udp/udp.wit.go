@@ -258,6 +258,175 @@ func (self UDPSocket) UnicastHopLimit() (result cm.Result[uint8, uint8, network.
 //go:noescape
 func wasmimport_UDPSocketUnicastHopLimit(self0 uint32, result *cm.Result[uint8, uint8, network.ErrorCode])
 
+// JoinMulticastGroupV4 represents the imported method "join-multicast-group-v4".
+//
+//	join-multicast-group-v4: func(network: borrow<network>, group: ipv4-address, interface: u32) ->
+//	result<_, error-code>
+//
+//go:nosplit
+func (self UDPSocket) JoinMulticastGroupV4(network_ network.Network, group network.Ipv4Address, interface_ uint32) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	network0 := cm.Reinterpret[uint32](network_)
+	group0, group1, group2, group3 := lower_Ipv4Address(group)
+	interface0 := (uint32)(interface_)
+	wasmimport_UDPSocketJoinMulticastGroupV4((uint32)(self0), (uint32)(network0), (uint32)(group0), (uint32)(group1), (uint32)(group2), (uint32)(group3), (uint32)(interface0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.join-multicast-group-v4
+//go:noescape
+func wasmimport_UDPSocketJoinMulticastGroupV4(self0 uint32, network0 uint32, group0 uint32, group1 uint32, group2 uint32, group3 uint32, interface0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+
+// JoinMulticastGroupV6 represents the imported method "join-multicast-group-v6".
+//
+//	join-multicast-group-v6: func(network: borrow<network>, group: ipv6-address, interface: u32) ->
+//	result<_, error-code>
+//
+//go:nosplit
+func (self UDPSocket) JoinMulticastGroupV6(network_ network.Network, group network.Ipv6Address, interface_ uint32) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	network0 := cm.Reinterpret[uint32](network_)
+	group0, group1, group2, group3, group4, group5, group6, group7 := lower_Ipv6Address(group)
+	interface0 := (uint32)(interface_)
+	wasmimport_UDPSocketJoinMulticastGroupV6((uint32)(self0), (uint32)(network0), (uint32)(group0), (uint32)(group1), (uint32)(group2), (uint32)(group3), (uint32)(group4), (uint32)(group5), (uint32)(group6), (uint32)(group7), (uint32)(interface0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.join-multicast-group-v6
+//go:noescape
+func wasmimport_UDPSocketJoinMulticastGroupV6(self0 uint32, network0 uint32, group0 uint32, group1 uint32, group2 uint32, group3 uint32, group4 uint32, group5 uint32, group6 uint32, group7 uint32, interface0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+
+// LeaveMulticastGroupV4 represents the imported method "leave-multicast-group-v4".
+//
+//	leave-multicast-group-v4: func(network: borrow<network>, group: ipv4-address, interface: u32) ->
+//	result<_, error-code>
+//
+//go:nosplit
+func (self UDPSocket) LeaveMulticastGroupV4(network_ network.Network, group network.Ipv4Address, interface_ uint32) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	network0 := cm.Reinterpret[uint32](network_)
+	group0, group1, group2, group3 := lower_Ipv4Address(group)
+	interface0 := (uint32)(interface_)
+	wasmimport_UDPSocketLeaveMulticastGroupV4((uint32)(self0), (uint32)(network0), (uint32)(group0), (uint32)(group1), (uint32)(group2), (uint32)(group3), (uint32)(interface0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.leave-multicast-group-v4
+//go:noescape
+func wasmimport_UDPSocketLeaveMulticastGroupV4(self0 uint32, network0 uint32, group0 uint32, group1 uint32, group2 uint32, group3 uint32, interface0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+
+// LeaveMulticastGroupV6 represents the imported method "leave-multicast-group-v6".
+//
+//	leave-multicast-group-v6: func(network: borrow<network>, group: ipv6-address, interface: u32) ->
+//	result<_, error-code>
+//
+//go:nosplit
+func (self UDPSocket) LeaveMulticastGroupV6(network_ network.Network, group network.Ipv6Address, interface_ uint32) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	network0 := cm.Reinterpret[uint32](network_)
+	group0, group1, group2, group3, group4, group5, group6, group7 := lower_Ipv6Address(group)
+	interface0 := (uint32)(interface_)
+	wasmimport_UDPSocketLeaveMulticastGroupV6((uint32)(self0), (uint32)(network0), (uint32)(group0), (uint32)(group1), (uint32)(group2), (uint32)(group3), (uint32)(group4), (uint32)(group5), (uint32)(group6), (uint32)(group7), (uint32)(interface0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.leave-multicast-group-v6
+//go:noescape
+func wasmimport_UDPSocketLeaveMulticastGroupV6(self0 uint32, network0 uint32, group0 uint32, group1 uint32, group2 uint32, group3 uint32, group4 uint32, group5 uint32, group6 uint32, group7 uint32, interface0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+
+// MulticastHopLimit represents the imported method "multicast-hop-limit".
+//
+//	multicast-hop-limit: func() -> result<u8, error-code>
+//
+//go:nosplit
+func (self UDPSocket) MulticastHopLimit() (result cm.Result[uint8, uint8, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	wasmimport_UDPSocketMulticastHopLimit((uint32)(self0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.multicast-hop-limit
+//go:noescape
+func wasmimport_UDPSocketMulticastHopLimit(self0 uint32, result *cm.Result[uint8, uint8, network.ErrorCode])
+
+// SetMulticastHopLimit represents the imported method "set-multicast-hop-limit".
+//
+//	set-multicast-hop-limit: func(value: u8) -> result<_, error-code>
+//
+//go:nosplit
+func (self UDPSocket) SetMulticastHopLimit(value uint8) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	value0 := (uint32)(value)
+	wasmimport_UDPSocketSetMulticastHopLimit((uint32)(self0), (uint32)(value0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.set-multicast-hop-limit
+//go:noescape
+func wasmimport_UDPSocketSetMulticastHopLimit(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+
+// MulticastLoopbackV4 represents the imported method "multicast-loopback-v4".
+//
+//	multicast-loopback-v4: func() -> result<bool, error-code>
+//
+//go:nosplit
+func (self UDPSocket) MulticastLoopbackV4() (result cm.Result[bool, bool, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	wasmimport_UDPSocketMulticastLoopbackV4((uint32)(self0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.multicast-loopback-v4
+//go:noescape
+func wasmimport_UDPSocketMulticastLoopbackV4(self0 uint32, result *cm.Result[bool, bool, network.ErrorCode])
+
+// SetMulticastLoopbackV4 represents the imported method "set-multicast-loopback-v4".
+//
+//	set-multicast-loopback-v4: func(value: bool) -> result<_, error-code>
+//
+//go:nosplit
+func (self UDPSocket) SetMulticastLoopbackV4(value bool) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	value0 := cm.BoolToU32(value)
+	wasmimport_UDPSocketSetMulticastLoopbackV4((uint32)(self0), (uint32)(value0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.set-multicast-loopback-v4
+//go:noescape
+func wasmimport_UDPSocketSetMulticastLoopbackV4(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+
+// MulticastLoopbackV6 represents the imported method "multicast-loopback-v6".
+//
+//	multicast-loopback-v6: func() -> result<bool, error-code>
+//
+//go:nosplit
+func (self UDPSocket) MulticastLoopbackV6() (result cm.Result[bool, bool, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	wasmimport_UDPSocketMulticastLoopbackV6((uint32)(self0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.multicast-loopback-v6
+//go:noescape
+func wasmimport_UDPSocketMulticastLoopbackV6(self0 uint32, result *cm.Result[bool, bool, network.ErrorCode])
+
+// SetMulticastLoopbackV6 represents the imported method "set-multicast-loopback-v6".
+//
+//	set-multicast-loopback-v6: func(value: bool) -> result<_, error-code>
+//
+//go:nosplit
+func (self UDPSocket) SetMulticastLoopbackV6(value bool) (result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]) {
+	self0 := cm.Reinterpret[uint32](self)
+	value0 := cm.BoolToU32(value)
+	wasmimport_UDPSocketSetMulticastLoopbackV6((uint32)(self0), (uint32)(value0), &result)
+	return
+}
+
+//go:wasmimport wasi:sockets/udp@0.2.0 [method]udp-socket.set-multicast-loopback-v6
+//go:noescape
+func wasmimport_UDPSocketSetMulticastLoopbackV6(self0 uint32, value0 uint32, result *cm.Result[network.ErrorCode, struct{}, network.ErrorCode])
+
 // IncomingDatagramStream represents the imported resource "wasi:sockets/udp@0.2.0#incoming-datagram-stream".
 //
 //	resource incoming-datagram-stream
@@ -13,7 +13,10 @@ import (
 
 var (
 	wasiTransport = &wasihttp.Transport{}
-	httpClient    = &http.Client{Transport: wasiTransport}
+	httpClient    = &http.Client{Transport: Wrap(wasiTransport,
+		WithOTelPropagation(),
+		WithRetry(DefaultRetryPolicy()),
+	)}
 )
 
 func init() {
@@ -0,0 +1,281 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"log/slog"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+)
+
+// readAndReplaceBody reads req.Body in full, replaces it with a fresh reader over the same
+// bytes, and returns the bytes so later retry attempts can rebuild the body from scratch.
+func readAndReplaceBody(req *http.Request) ([]byte, error) {
+	buf, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	req.Body = newBodyReader(buf)
+	return buf, nil
+}
+
+func newBodyReader(buf []byte) io.ReadCloser {
+	return io.NopCloser(bytes.NewReader(buf))
+}
+
+// roundTripperFunc adapts a function to http.RoundTripper, following the same pattern as
+// net/http's own RoundTripper chains.
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// TransportOption wraps a RoundTripper with a cross-cutting concern (retry, circuit breaking,
+// tracing, logging, ...). Options are applied in the order they're passed to Wrap, so the first
+// option given becomes the outermost layer of the chain.
+//
+// NOTE: this lives alongside the example rather than in go.wasmcloud.dev/component/net/wasihttp
+// because that package isn't part of this tree; wasihttp.Transport already satisfies
+// http.RoundTripper, so these options compose with it (or any other RoundTripper) unmodified.
+type TransportOption func(http.RoundTripper) http.RoundTripper
+
+// Wrap builds a RoundTripper by applying each TransportOption around base, outermost first.
+func Wrap(base http.RoundTripper, opts ...TransportOption) http.RoundTripper {
+	rt := base
+	for i := len(opts) - 1; i >= 0; i-- {
+		rt = opts[i](rt)
+	}
+	return rt
+}
+
+// RetryPolicy configures WithRetry's exponential backoff and which requests are eligible for a
+// retry attempt.
+type RetryPolicy struct {
+	MaxAttempts    int
+	BaseDelay      time.Duration
+	MaxDelay       time.Duration
+	RetryStatuses  map[int]bool
+	retryIdempOnly bool
+}
+
+// DefaultRetryPolicy retries idempotent methods (GET/HEAD) and common transient gateway status
+// codes, up to 3 attempts with jittered exponential backoff starting at 100ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:    3,
+		BaseDelay:      100 * time.Millisecond,
+		MaxDelay:       2 * time.Second,
+		RetryStatuses:  map[int]bool{502: true, 503: true, 504: true},
+		retryIdempOnly: true,
+	}
+}
+
+// WithRetry retries requests that fail with a connection-level error or an allow-listed status
+// code, using exponential backoff with jitter. Request bodies are buffered up front since the
+// underlying wasi:http stream can't be rewound for a second attempt.
+//
+// A connection-level error (no response reached the server) is retried regardless of method,
+// since it's never unsafe to resend a request the server never saw. A retryable status code,
+// meaning the server did respond, is only retried for GET/HEAD when policy.retryIdempOnly is set,
+// since resending e.g. a POST that reached the server risks double-applying it.
+func WithRetry(policy RetryPolicy) TransportOption {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			idempotent := req.Method == http.MethodGet || req.Method == http.MethodHead
+
+			var bodyBytes []byte
+			if req.Body != nil {
+				buf, err := readAndReplaceBody(req)
+				if err != nil {
+					return nil, err
+				}
+				bodyBytes = buf
+			}
+
+			var resp *http.Response
+			var err error
+			for attempt := 0; attempt < policy.MaxAttempts; attempt++ {
+				if attempt > 0 {
+					time.Sleep(backoffWithJitter(policy, attempt))
+				}
+				if bodyBytes != nil {
+					req.Body = newBodyReader(bodyBytes)
+				}
+				resp, err = next.RoundTrip(req)
+
+				retryableStatus := err == nil && policy.RetryStatuses[resp.StatusCode] && (!policy.retryIdempOnly || idempotent)
+				if err == nil && !retryableStatus {
+					return resp, nil
+				}
+
+				lastAttempt := attempt == policy.MaxAttempts-1
+				if err == nil && !lastAttempt {
+					resp.Body.Close()
+				}
+			}
+			return resp, err
+		})
+	}
+}
+
+// minRetryBackoffDelay floors backoffWithJitter's delay so a RetryPolicy built without setting
+// BaseDelay (the zero value of any RetryPolicy{} literal, not just DefaultRetryPolicy's) can't
+// drive rand.Int63n with an n <= 0, which panics per its documented contract.
+const minRetryBackoffDelay = time.Millisecond
+
+func backoffWithJitter(policy RetryPolicy, attempt int) time.Duration {
+	delay := policy.BaseDelay << uint(attempt-1)
+	if delay > policy.MaxDelay {
+		delay = policy.MaxDelay
+	}
+	if delay < minRetryBackoffDelay {
+		delay = minRetryBackoffDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(delay) / 2))
+	return delay/2 + jitter
+}
+
+// CircuitBreakerConfig configures WithCircuitBreaker's failure threshold and recovery probing.
+type CircuitBreakerConfig struct {
+	FailureThreshold float64
+	MinRequests      int
+	OpenDuration     time.Duration
+}
+
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// WithCircuitBreaker tracks the failure ratio of requests per destination host and stops sending
+// requests to a host once its failure ratio crosses cfg.FailureThreshold, allowing a single probe
+// request through after cfg.OpenDuration to test recovery.
+func WithCircuitBreaker(cfg CircuitBreakerConfig) TransportOption {
+	type hostStats struct {
+		mu       sync.Mutex
+		state    circuitState
+		total    int
+		failures int
+		openedAt time.Time
+	}
+	hosts := make(map[string]*hostStats)
+	var hostsMu sync.Mutex
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			hostsMu.Lock()
+			stats, ok := hosts[req.URL.Host]
+			if !ok {
+				stats = &hostStats{}
+				hosts[req.URL.Host] = stats
+			}
+			hostsMu.Unlock()
+
+			probe := false
+			stats.mu.Lock()
+			switch stats.state {
+			case circuitOpen:
+				if time.Since(stats.openedAt) < cfg.OpenDuration {
+					stats.mu.Unlock()
+					return nil, &CircuitOpenError{Host: req.URL.Host}
+				}
+				stats.state = circuitHalfOpen
+				probe = true
+			case circuitHalfOpen:
+				// The probe is still in flight; hold other requests until it reports back.
+				stats.mu.Unlock()
+				return nil, &CircuitOpenError{Host: req.URL.Host}
+			}
+			stats.mu.Unlock()
+
+			resp, err := next.RoundTrip(req)
+
+			stats.mu.Lock()
+			defer stats.mu.Unlock()
+			failed := err != nil || resp.StatusCode >= 500
+			stats.total++
+			if failed {
+				stats.failures++
+			}
+			if probe {
+				if failed {
+					stats.state = circuitOpen
+					stats.openedAt = time.Now()
+				} else {
+					stats.state = circuitClosed
+					stats.total, stats.failures = 0, 0
+				}
+			} else if stats.total >= cfg.MinRequests && float64(stats.failures)/float64(stats.total) >= cfg.FailureThreshold {
+				stats.state = circuitOpen
+				stats.openedAt = time.Now()
+			}
+
+			return resp, err
+		})
+	}
+}
+
+// CircuitOpenError is returned by a RoundTripper wrapped with WithCircuitBreaker when the breaker
+// for a destination host is open.
+type CircuitOpenError struct{ Host string }
+
+func (e *CircuitOpenError) Error() string { return "circuit breaker open for host " + e.Host }
+
+// WithOTelPropagation injects the current trace context into outbound request headers using the
+// globally configured otel propagator, so the wasi:http host (and whatever it calls next) can
+// continue the trace.
+func WithOTelPropagation() TransportOption {
+	return func(next http.RoundTripper) http.RoundTripper {
+		propagator := otel.GetTextMapPropagator()
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			propagator.Inject(req.Context(), httpHeaderCarrier(req.Header))
+			return next.RoundTrip(req)
+		})
+	}
+}
+
+type httpHeaderCarrier http.Header
+
+func (c httpHeaderCarrier) Get(key string) string { return http.Header(c).Get(key) }
+func (c httpHeaderCarrier) Set(key, value string) { http.Header(c).Set(key, value) }
+func (c httpHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// WithRequestLogger logs the method, URL, and outcome of every outbound request at debug level.
+func WithRequestLogger(logger *slog.Logger) TransportOption {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next.RoundTrip(req)
+			if err != nil {
+				logger.Debug("outbound request failed", "method", req.Method, "url", req.URL.String(), "err", err, "elapsed", time.Since(start))
+				return resp, err
+			}
+			logger.Debug("outbound request", "method", req.Method, "url", req.URL.String(), "status", resp.StatusCode, "elapsed", time.Since(start))
+			return resp, err
+		})
+	}
+}
+
+// WithHeaderInjector mutates each outbound request with inject before it's sent, e.g. to set a
+// static API key or a user-agent header.
+func WithHeaderInjector(inject func(*http.Request)) TransportOption {
+	return func(next http.RoundTripper) http.RoundTripper {
+		return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			inject(req)
+			return next.RoundTrip(req)
+		})
+	}
+}
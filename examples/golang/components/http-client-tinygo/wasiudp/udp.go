@@ -0,0 +1,536 @@
+// Package wasiudp adapts the wit-bindgen-go output in gen/wasi/sockets/udp to Go's standard
+// net.Conn and net.PacketConn interfaces, the same way the Go standard library's wasip1 port
+// wraps its raw syscalls in src/net/fd_wasip1.go. Callers get ordinary Dial/ListenPacket-style Go
+// instead of juggling cm.Result, cm.Option, and pollable subscriptions themselves, which means
+// networking code written against net.Conn (DNS clients, QUIC, metrics exporters) runs inside a
+// wasmCloud component unmodified.
+//
+// This package depends on gen packages for wasi:sockets/network, wasi:sockets/instance-network,
+// wasi:sockets/udp-create-socket, and wasi:io/poll in addition to gen/wasi/sockets/udp; only the
+// latter is checked into this example's gen/ directory today; run `go generate` against a world
+// that imports all four before building this package.
+//
+// Multicast support (JoinGroup/LeaveGroup, MulticastGroup) is guest-side only: it calls into the
+// udp-socket bindings the same way the rest of this package does. There is no wasmCloud host
+// runtime checked into this repository to extend with a matching capability-provider
+// implementation, so the host-side half of the wasi:sockets multicast surface isn't covered here.
+package wasiudp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/bytecodealliance/wasm-tools-go/cm"
+	"github.com/wasmcloud/wasmcloud/examples/golang/components/http-client-tinygo/gen/wasi/clocks/monotonicclock"
+	"github.com/wasmcloud/wasmcloud/examples/golang/components/http-client-tinygo/gen/wasi/io/poll"
+	"github.com/wasmcloud/wasmcloud/examples/golang/components/http-client-tinygo/gen/wasi/sockets/instancenetwork"
+	"github.com/wasmcloud/wasmcloud/examples/golang/components/http-client-tinygo/gen/wasi/sockets/network"
+	"github.com/wasmcloud/wasmcloud/examples/golang/components/http-client-tinygo/gen/wasi/sockets/udp"
+	udpcreatesocket "github.com/wasmcloud/wasmcloud/examples/golang/components/http-client-tinygo/gen/wasi/sockets/udp-create-socket"
+)
+
+// Conn is a UDP socket, bound via ListenPacket or additionally connected to a single peer via
+// Dial. It implements both net.Conn and net.PacketConn; use whichever fits the caller.
+type Conn struct {
+	socket   udp.UDPSocket
+	incoming udp.IncomingDatagramStream
+	outgoing udp.OutgoingDatagramStream
+
+	laddr net.Addr
+	raddr net.Addr // nil unless connected via Dial
+
+	mu            sync.Mutex
+	closed        bool
+	readDeadline  time.Time
+	writeDeadline time.Time
+}
+
+var (
+	_ net.Conn       = (*Conn)(nil)
+	_ net.PacketConn = (*Conn)(nil)
+)
+
+// Dial connects to address over UDP, equivalent to net.Dial but backed by a wasi:sockets UDP
+// socket. network must be "udp", "udp4", or "udp6".
+func Dial(dialNetwork, address string) (*Conn, error) {
+	return DialContext(context.Background(), dialNetwork, address)
+}
+
+// DialContext is Dial with a context whose deadline/cancellation aborts the bind+connect.
+func DialContext(ctx context.Context, dialNetwork, address string) (*Conn, error) {
+	family, err := addressFamily(dialNetwork)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: dialNetwork, Err: err}
+	}
+
+	remoteAddr, err := net.ResolveUDPAddr(dialNetwork, address)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: dialNetwork, Err: err}
+	}
+	remote := ipSocketAddressFromUDPAddr(remoteAddr)
+
+	socket, err := createSocket(family)
+	if err != nil {
+		return nil, &net.OpError{Op: "dial", Net: dialNetwork, Addr: remoteAddr, Err: err}
+	}
+
+	if err := bind(ctx, socket, wildcardAddress(family)); err != nil {
+		socket.ResourceDrop()
+		return nil, &net.OpError{Op: "dial", Net: dialNetwork, Addr: remoteAddr, Err: err}
+	}
+
+	incoming, outgoing, err := stream(socket, cm.Some(remote))
+	if err != nil {
+		socket.ResourceDrop()
+		return nil, &net.OpError{Op: "dial", Net: dialNetwork, Addr: remoteAddr, Err: err}
+	}
+
+	laddr, err := localAddr(socket)
+	if err != nil {
+		laddr = &net.UDPAddr{}
+	}
+
+	c := &Conn{socket: socket, incoming: incoming, outgoing: outgoing, laddr: laddr, raddr: remoteAddr}
+	runtime.SetFinalizer(c, (*Conn).Close)
+	return c, nil
+}
+
+// ListenPacket opens a UDP socket bound to address (which may have an empty host/port to bind to
+// a wildcard address/ephemeral port), equivalent to net.ListenPacket but backed by a wasi:sockets
+// UDP socket. network must be "udp", "udp4", or "udp6".
+func ListenPacket(listenNetwork, address string) (*Conn, error) {
+	family, err := addressFamily(listenNetwork)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: listenNetwork, Err: err}
+	}
+
+	local := wildcardAddress(family)
+	if address != "" {
+		addr, err := net.ResolveUDPAddr(listenNetwork, address)
+		if err != nil {
+			return nil, &net.OpError{Op: "listen", Net: listenNetwork, Err: err}
+		}
+		local = ipSocketAddressFromUDPAddr(addr)
+	}
+
+	socket, err := createSocket(family)
+	if err != nil {
+		return nil, &net.OpError{Op: "listen", Net: listenNetwork, Err: err}
+	}
+
+	if err := bind(context.Background(), socket, local); err != nil {
+		socket.ResourceDrop()
+		return nil, &net.OpError{Op: "listen", Net: listenNetwork, Err: err}
+	}
+
+	incoming, outgoing, err := stream(socket, cm.None[network.IPSocketAddress]())
+	if err != nil {
+		socket.ResourceDrop()
+		return nil, &net.OpError{Op: "listen", Net: listenNetwork, Err: err}
+	}
+
+	laddr, err := localAddr(socket)
+	if err != nil {
+		laddr = &net.UDPAddr{}
+	}
+
+	c := &Conn{socket: socket, incoming: incoming, outgoing: outgoing, laddr: laddr}
+	runtime.SetFinalizer(c, (*Conn).Close)
+	return c, nil
+}
+
+func createSocket(family network.IPAddressFamily) (udp.UDPSocket, error) {
+	result := udpcreatesocket.CreateUDPSocket(family)
+	if err := result.Err(); err != nil {
+		return udp.UDPSocket{}, errFromErrorCode(*err)
+	}
+	return *result.OK(), nil
+}
+
+// bind runs wasi:sockets' two-phase bind (StartBind, then poll the socket's own pollable until
+// FinishBind stops returning would-block), so callers never see the WASI-specific retry loop.
+func bind(ctx context.Context, socket udp.UDPSocket, local network.IPSocketAddress) error {
+	net_ := instancenetwork.InstanceNetwork()
+
+	if err := socket.StartBind(net_, local).Err(); err != nil {
+		return errFromErrorCode(*err)
+	}
+
+	pollable := socket.Subscribe()
+	defer pollable.ResourceDrop()
+
+	for {
+		if err := socket.FinishBind().Err(); err != nil {
+			if *err == network.ErrorCodeWouldBlock {
+				if err := waitReady(ctx, pollable, time.Time{}); err != nil {
+					return err
+				}
+				continue
+			}
+			return errFromErrorCode(*err)
+		}
+		return nil
+	}
+}
+
+func stream(socket udp.UDPSocket, remote cm.Option[network.IPSocketAddress]) (udp.IncomingDatagramStream, udp.OutgoingDatagramStream, error) {
+	result := socket.Stream(remote)
+	if err := result.Err(); err != nil {
+		return udp.IncomingDatagramStream{}, udp.OutgoingDatagramStream{}, errFromErrorCode(*err)
+	}
+	pair := *result.OK()
+	return pair.V0, pair.V1, nil
+}
+
+func localAddr(socket udp.UDPSocket) (net.Addr, error) {
+	result := socket.LocalAddress()
+	if err := result.Err(); err != nil {
+		return nil, errFromErrorCode(*err)
+	}
+	return udpAddrFromIPSocketAddress(*result.OK()), nil
+}
+
+// Read implements net.Conn. It's only valid on a Conn returned by Dial; call ReadFrom on a Conn
+// from ListenPacket instead.
+func (c *Conn) Read(b []byte) (int, error) {
+	n, _, err := c.ReadFrom(b)
+	return n, err
+}
+
+// Write implements net.Conn. It's only valid on a Conn returned by Dial; call WriteTo on a Conn
+// from ListenPacket instead.
+func (c *Conn) Write(b []byte) (int, error) {
+	return c.WriteTo(b, c.raddr)
+}
+
+// ReadFrom implements net.PacketConn, returning the next datagram and the address it arrived
+// from.
+func (c *Conn) ReadFrom(b []byte) (int, net.Addr, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return 0, nil, net.ErrClosed
+	}
+	deadline := c.readDeadline
+	c.mu.Unlock()
+
+	pollable := c.incoming.Subscribe()
+	defer pollable.ResourceDrop()
+
+	for {
+		result := c.incoming.Receive(1)
+		if err := result.Err(); err != nil {
+			if *err == network.ErrorCodeWouldBlock {
+				if err := waitReady(context.Background(), pollable, deadline); err != nil {
+					return 0, nil, &net.OpError{Op: "read", Net: "udp", Addr: c.laddr, Err: err}
+				}
+				continue
+			}
+			return 0, nil, &net.OpError{Op: "read", Net: "udp", Addr: c.laddr, Err: errFromErrorCode(*err)}
+		}
+
+		datagrams := result.OK().Slice()
+		if len(datagrams) == 0 {
+			if err := waitReady(context.Background(), pollable, deadline); err != nil {
+				return 0, nil, &net.OpError{Op: "read", Net: "udp", Addr: c.laddr, Err: err}
+			}
+			continue
+		}
+
+		datagram := datagrams[0]
+		n := copy(b, datagram.Data.Slice())
+		return n, udpAddrFromIPSocketAddress(datagram.RemoteAddress), nil
+	}
+}
+
+// WriteTo implements net.PacketConn, sending b as a single datagram to addr.
+func (c *Conn) WriteTo(b []byte, addr net.Addr) (int, error) {
+	c.mu.Lock()
+	if c.closed {
+		c.mu.Unlock()
+		return 0, net.ErrClosed
+	}
+	deadline := c.writeDeadline
+	c.mu.Unlock()
+
+	udpAddr, ok := addr.(*net.UDPAddr)
+	if !ok {
+		return 0, &net.OpError{Op: "write", Net: "udp", Addr: addr, Err: fmt.Errorf("wasiudp: addr must be a *net.UDPAddr, got %T", addr)}
+	}
+
+	datagram := udp.OutgoingDatagram{
+		Data:          cm.NewList(&b[0], len(b)),
+		RemoteAddress: cm.Some(ipSocketAddressFromUDPAddr(udpAddr)),
+	}
+
+	pollable := c.outgoing.Subscribe()
+	defer pollable.ResourceDrop()
+
+	for {
+		if check := c.outgoing.CheckSend(); check.Err() == nil && *check.OK() == 0 {
+			if err := waitReady(context.Background(), pollable, deadline); err != nil {
+				return 0, &net.OpError{Op: "write", Net: "udp", Addr: addr, Err: err}
+			}
+			continue
+		}
+
+		result := c.outgoing.Send(cm.ToList([]udp.OutgoingDatagram{datagram}))
+		if err := result.Err(); err != nil {
+			if *err == network.ErrorCodeWouldBlock {
+				if err := waitReady(context.Background(), pollable, deadline); err != nil {
+					return 0, &net.OpError{Op: "write", Net: "udp", Addr: addr, Err: err}
+				}
+				continue
+			}
+			return 0, &net.OpError{Op: "write", Net: "udp", Addr: addr, Err: errFromErrorCode(*err)}
+		}
+		return len(b), nil
+	}
+}
+
+// Close releases the underlying WASI socket and stream resources. It's safe to call multiple
+// times and is also called by a finalizer if the caller forgets, so handles are never leaked.
+func (c *Conn) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	runtime.SetFinalizer(c, nil)
+
+	c.incoming.ResourceDrop()
+	c.outgoing.ResourceDrop()
+	c.socket.ResourceDrop()
+	return nil
+}
+
+func (c *Conn) LocalAddr() net.Addr  { return c.laddr }
+func (c *Conn) RemoteAddr() net.Addr { return c.raddr }
+
+// MulticastGroup identifies a multicast group to join or leave: the group address plus the
+// index of the local interface to join it on (0 lets the host choose).
+type MulticastGroup struct {
+	Addr      netip.Addr
+	Interface uint32
+}
+
+// JoinGroup joins the multicast group, so subsequent ReadFrom calls also receive datagrams sent
+// to group.Addr. Matches the semantics of (*net.UDPConn).JoinGroup, but takes the WASI interface
+// index directly instead of a *net.Interface.
+func (c *Conn) JoinGroup(group MulticastGroup) error {
+	return c.multicastGroupOp("join-multicast-group", group, c.socket.JoinMulticastGroupV4, c.socket.JoinMulticastGroupV6)
+}
+
+// LeaveGroup leaves a multicast group previously joined with JoinGroup.
+func (c *Conn) LeaveGroup(group MulticastGroup) error {
+	return c.multicastGroupOp("leave-multicast-group", group, c.socket.LeaveMulticastGroupV4, c.socket.LeaveMulticastGroupV6)
+}
+
+func (c *Conn) multicastGroupOp(
+	op string,
+	group MulticastGroup,
+	v4 func(network.Network, network.Ipv4Address, uint32) cm.Result[network.ErrorCode, struct{}, network.ErrorCode],
+	v6 func(network.Network, network.Ipv6Address, uint32) cm.Result[network.ErrorCode, struct{}, network.ErrorCode],
+) error {
+	net_ := instancenetwork.InstanceNetwork()
+
+	var result cm.Result[network.ErrorCode, struct{}, network.ErrorCode]
+	switch {
+	case group.Addr.Is4():
+		result = v4(net_, ipv4AddressFromNetIP(group.Addr), group.Interface)
+	case group.Addr.Is6():
+		result = v6(net_, ipv6AddressFromNetIP(group.Addr), group.Interface)
+	default:
+		return &net.OpError{Op: op, Net: "udp", Addr: c.laddr, Err: fmt.Errorf("wasiudp: invalid multicast address %v", group.Addr)}
+	}
+
+	if err := result.Err(); err != nil {
+		return &net.OpError{Op: op, Net: "udp", Addr: c.laddr, Err: errFromErrorCode(*err)}
+	}
+	return nil
+}
+
+// SetMulticastHopLimit sets the TTL used for outgoing multicast datagrams.
+func (c *Conn) SetMulticastHopLimit(limit uint8) error {
+	if err := c.socket.SetMulticastHopLimit(limit).Err(); err != nil {
+		return &net.OpError{Op: "set-multicast-hop-limit", Net: "udp", Addr: c.laddr, Err: errFromErrorCode(*err)}
+	}
+	return nil
+}
+
+// SetMulticastLoopbackV4 controls whether IPv4 multicast datagrams sent on this socket are
+// looped back to local listeners.
+func (c *Conn) SetMulticastLoopbackV4(loop bool) error {
+	if err := c.socket.SetMulticastLoopbackV4(loop).Err(); err != nil {
+		return &net.OpError{Op: "set-multicast-loopback-v4", Net: "udp", Addr: c.laddr, Err: errFromErrorCode(*err)}
+	}
+	return nil
+}
+
+// SetMulticastLoopbackV6 is SetMulticastLoopbackV4 for IPv6 multicast datagrams.
+func (c *Conn) SetMulticastLoopbackV6(loop bool) error {
+	if err := c.socket.SetMulticastLoopbackV6(loop).Err(); err != nil {
+		return &net.OpError{Op: "set-multicast-loopback-v6", Net: "udp", Addr: c.laddr, Err: errFromErrorCode(*err)}
+	}
+	return nil
+}
+
+func ipv4AddressFromNetIP(addr netip.Addr) network.Ipv4Address {
+	a4 := addr.As4()
+	return network.Ipv4Address{a4[0], a4[1], a4[2], a4[3]}
+}
+
+func ipv6AddressFromNetIP(addr netip.Addr) network.Ipv6Address {
+	a16 := addr.As16()
+	var segments network.Ipv6Address
+	for i := range segments {
+		segments[i] = uint16(a16[i*2])<<8 | uint16(a16[i*2+1])
+	}
+	return segments
+}
+
+func (c *Conn) SetDeadline(t time.Time) error {
+	if err := c.SetReadDeadline(t); err != nil {
+		return err
+	}
+	return c.SetWriteDeadline(t)
+}
+
+func (c *Conn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readDeadline = t
+	return nil
+}
+
+func (c *Conn) SetWriteDeadline(t time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.writeDeadline = t
+	return nil
+}
+
+// waitReady blocks until pollable is ready, or returns a timeout error once deadline passes.
+// Composing the socket's pollable with a wasi:clocks/monotonic-clock timer pollable through
+// poll.Poll is what lets a single Go deadline cover an operation that's really "wait on one of
+// several WASI events", without spinning.
+func waitReady(ctx context.Context, pollable poll.Pollable, deadline time.Time) error {
+	pollables := []poll.Pollable{pollable}
+
+	if !deadline.IsZero() {
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return errDeadlineExceeded
+		}
+		timer := monotonicclock.SubscribeDuration(uint64(remaining.Nanoseconds()))
+		defer timer.ResourceDrop()
+		pollables = append(pollables, timer)
+	}
+
+	if ctx != nil && ctx.Done() != nil {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+	}
+
+	ready := poll.Poll(cm.ToList(pollables))
+	for _, idx := range ready.Slice() {
+		if int(idx) == 0 {
+			return nil
+		}
+	}
+	return errDeadlineExceeded
+}
+
+// errDeadlineExceeded is returned by waitReady once a read/write deadline passes.
+var errDeadlineExceeded error = &wasiTimeoutError{}
+
+type wasiTimeoutError struct{}
+
+func (*wasiTimeoutError) Error() string   { return "i/o timeout" }
+func (*wasiTimeoutError) Timeout() bool   { return true }
+func (*wasiTimeoutError) Temporary() bool { return true }
+
+func addressFamily(netw string) (network.IPAddressFamily, error) {
+	switch netw {
+	case "udp", "udp4":
+		return network.IPAddressFamilyIPv4, nil
+	case "udp6":
+		return network.IPAddressFamilyIPv6, nil
+	default:
+		return 0, &net.AddrError{Err: "unsupported network", Addr: netw}
+	}
+}
+
+func wildcardAddress(family network.IPAddressFamily) network.IPSocketAddress {
+	if family == network.IPAddressFamilyIPv6 {
+		return network.IPSocketAddressIPv6(network.IPv6SocketAddress{Port: 0})
+	}
+	return network.IPSocketAddressIPv4(network.IPv4SocketAddress{Port: 0})
+}
+
+func ipSocketAddressFromUDPAddr(addr *net.UDPAddr) network.IPSocketAddress {
+	if ip4 := addr.IP.To4(); ip4 != nil {
+		return network.IPSocketAddressIPv4(network.IPv4SocketAddress{
+			Port:    uint16(addr.Port),
+			Address: [4]uint8{ip4[0], ip4[1], ip4[2], ip4[3]},
+		})
+	}
+
+	var segments [8]uint16
+	ip16 := addr.IP.To16()
+	for i := range segments {
+		segments[i] = uint16(ip16[i*2])<<8 | uint16(ip16[i*2+1])
+	}
+	return network.IPSocketAddressIPv6(network.IPv6SocketAddress{Port: uint16(addr.Port), Address: segments})
+}
+
+func udpAddrFromIPSocketAddress(addr network.IPSocketAddress) *net.UDPAddr {
+	if v4, ok := addr.IPv4(); ok {
+		return &net.UDPAddr{IP: net.IPv4(v4.Address[0], v4.Address[1], v4.Address[2], v4.Address[3]), Port: int(v4.Port)}
+	}
+
+	v6, _ := addr.IPv6()
+	ip := make(net.IP, 16)
+	for i, segment := range v6.Address {
+		ip[i*2] = byte(segment >> 8)
+		ip[i*2+1] = byte(segment)
+	}
+	return &net.UDPAddr{IP: ip, Port: int(v6.Port)}
+}
+
+// errFromErrorCode translates a wasi:sockets/network error-code into a Go error. Only
+// ErrorCodeTimeout maps to an error implementing net.Error (with Timeout() true); the callers wrap
+// every result in a *net.OpError, so the usual `if ne, ok := err.(net.Error); ok && ne.Timeout()`
+// check still works on what they return.
+func errFromErrorCode(code network.ErrorCode) error {
+	switch code {
+	case network.ErrorCodeTimeout:
+		return &wasiTimeoutError{}
+	case network.ErrorCodeWouldBlock:
+		return errors.New("wasiudp: operation would block")
+	case network.ErrorCodeAddressInUse:
+		return errors.New("address already in use")
+	case network.ErrorCodeAddressNotBindable:
+		return errors.New("cannot assign requested address")
+	case network.ErrorCodeDatagramTooLarge:
+		return errors.New("wasiudp: datagram too large")
+	case network.ErrorCodeRemoteUnreachable:
+		return errors.New("network is unreachable")
+	case network.ErrorCodeConnectionRefused:
+		return errors.New("connection refused")
+	case network.ErrorCodeInvalidArgument:
+		return fmt.Errorf("wasiudp: invalid argument")
+	default:
+		return fmt.Errorf("wasiudp: %v", code)
+	}
+}
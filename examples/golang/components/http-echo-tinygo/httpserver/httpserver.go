@@ -0,0 +1,288 @@
+// Package httpserver adapts an ordinary net/http.Handler to the wasi:http/incoming-handler
+// export this component's wit/echo.wit world requires, so existing Go HTTP handlers
+// (http.ServeMux, chi, gorilla/mux, middleware chains) run inside the component unmodified
+// instead of every example hand-rolling the WASI request/response plumbing.
+//
+// NOTE: wit-bindgen generates a fresh, non-interchangeable Go package per component (see gen/,
+// not checked into this example), so this package is scoped to this component's own bindings
+// rather than a shared module; porting it to another tinygo component means copying the file and
+// pointing the gen import at that component's generated package.
+package httpserver
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	echo "github.com/wasmcloud/wasmcloud/examples/golang/components/http-echo-tinygo/gen"
+)
+
+// bodyReadChunkSize bounds each call into the incoming body's input-stream. wasi:io/streams
+// allows read to return fewer bytes than requested, so requestBody loops on it rather than
+// assuming (as this example's handwritten version used to) that a single call drains the body.
+const bodyReadChunkSize = 4096
+
+// maxWriteChunk bounds each BlockingWriteAndFlush call on the outgoing response stream, so a
+// large response body is flushed incrementally instead of in one unbounded write.
+const maxWriteChunk = 4096
+
+// New adapts next into the wasi:http/incoming-handler Handle method: build it once in init() and
+// register it with echo.SetExportsWasiHttp0_2_0_IncomingHandler.
+func New(next http.Handler) *Handler {
+	return &Handler{next: next}
+}
+
+// Handler implements the generated incoming-handler export interface.
+type Handler struct {
+	next http.Handler
+}
+
+// Handle is the wasi:http/incoming-handler export. It builds a *http.Request from req, runs it
+// through the wrapped handler, and streams the result back through out.
+func (h *Handler) Handle(req echo.ExportsWasiHttp0_2_0_IncomingHandlerIncomingRequest, out echo.WasiHttp0_2_0_TypesResponseOutparam) {
+	httpReq, err := buildRequest(req)
+	if err != nil {
+		writeError(out, err)
+		return
+	}
+
+	w := newResponseWriter(out)
+	h.next.ServeHTTP(w, httpReq)
+	w.finish()
+}
+
+func buildRequest(req echo.ExportsWasiHttp0_2_0_IncomingHandlerIncomingRequest) (*http.Request, error) {
+	pathWithQuery := "/"
+	if p := req.PathWithQuery(); p.IsSome() {
+		pathWithQuery = p.Unwrap()
+	}
+	u, err := url.ParseRequestURI(pathWithQuery)
+	if err != nil {
+		return nil, fmt.Errorf("httpserver: invalid path %q: %w", pathWithQuery, err)
+	}
+
+	body, err := newRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+
+	httpReq, err := http.NewRequest(requestMethod(req), u.String(), body)
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header = requestHeaders(req)
+	return httpReq, nil
+}
+
+func requestMethod(req echo.ExportsWasiHttp0_2_0_IncomingHandlerIncomingRequest) string {
+	switch req.Method() {
+	case echo.WasiHttp0_2_0_TypesMethodGet():
+		return http.MethodGet
+	case echo.WasiHttp0_2_0_TypesMethodPost():
+		return http.MethodPost
+	case echo.WasiHttp0_2_0_TypesMethodPut():
+		return http.MethodPut
+	case echo.WasiHttp0_2_0_TypesMethodDelete():
+		return http.MethodDelete
+	case echo.WasiHttp0_2_0_TypesMethodPatch():
+		return http.MethodPatch
+	case echo.WasiHttp0_2_0_TypesMethodConnect():
+		return http.MethodConnect
+	case echo.WasiHttp0_2_0_TypesMethodHead():
+		return http.MethodHead
+	case echo.WasiHttp0_2_0_TypesMethodOptions():
+		return http.MethodOptions
+	case echo.WasiHttp0_2_0_TypesMethodTrace():
+		return http.MethodTrace
+	default:
+		return "OTHER"
+	}
+}
+
+func requestHeaders(req echo.ExportsWasiHttp0_2_0_IncomingHandlerIncomingRequest) http.Header {
+	header := make(http.Header)
+	for _, kv := range req.Headers().Entries() {
+		header.Add(kv.F0, string(kv.F1))
+	}
+	return header
+}
+
+// requestBody is an io.ReadCloser backed directly by the incoming request's input-stream: it
+// reads lazily in bodyReadChunkSize pieces rather than buffering the whole body up front, and
+// treats a StreamErrorKindClosed read error as io.EOF instead of a failure.
+type requestBody struct {
+	stream echo.WasiIo0_2_0_StreamsInputStream
+	buf    bytes.Buffer
+	closed bool
+}
+
+func newRequestBody(req echo.ExportsWasiHttp0_2_0_IncomingHandlerIncomingRequest) (*requestBody, error) {
+	maybeBody := req.Consume()
+	if maybeBody.IsErr() {
+		return nil, errors.New("httpserver: failed to consume request body")
+	}
+
+	maybeStream := maybeBody.Unwrap().Stream()
+	if maybeStream.IsErr() {
+		return nil, errors.New("httpserver: failed to open request body stream")
+	}
+
+	return &requestBody{stream: maybeStream.Unwrap()}, nil
+}
+
+func (b *requestBody) Read(p []byte) (int, error) {
+	for b.buf.Len() == 0 {
+		if b.closed {
+			return 0, io.EOF
+		}
+
+		result := b.stream.Read(bodyReadChunkSize)
+		if result.IsErr() {
+			if result.UnwrapErr().Kind() == echo.WasiIo0_2_0_StreamsStreamErrorKindClosed {
+				b.closed = true
+				return 0, io.EOF
+			}
+			return 0, fmt.Errorf("httpserver: failed to read request body: kind %v", result.UnwrapErr().Kind())
+		}
+		b.buf.Write(result.Unwrap())
+	}
+	return b.buf.Read(p)
+}
+
+func (b *requestBody) Close() error {
+	if !b.closed {
+		b.closed = true
+		b.stream.Drop()
+	}
+	return nil
+}
+
+// responseWriter implements http.ResponseWriter, deferring outgoing-response construction until
+// the first WriteHeader/Write so handlers can still call Header() beforehand, then flushing
+// subsequent Write calls straight into the WASI outgoing-body stream in maxWriteChunk pieces.
+type responseWriter struct {
+	out    echo.WasiHttp0_2_0_TypesResponseOutparam
+	header http.Header
+	status int
+
+	headerWritten bool
+	body          echo.WasiHttp0_2_0_TypesOutgoingBody
+	stream        echo.WasiIo0_2_0_StreamsOutputStream
+	err           error
+}
+
+func newResponseWriter(out echo.WasiHttp0_2_0_TypesResponseOutparam) *responseWriter {
+	return &responseWriter{out: out, header: make(http.Header), status: http.StatusOK}
+}
+
+func (w *responseWriter) Header() http.Header { return w.header }
+
+func (w *responseWriter) WriteHeader(status int) {
+	if w.headerWritten {
+		return
+	}
+	w.headerWritten = true
+	w.status = status
+	w.start()
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	if !w.headerWritten {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+
+	written := 0
+	for len(p) > 0 {
+		n := len(p)
+		if n > maxWriteChunk {
+			n = maxWriteChunk
+		}
+		if res := w.stream.BlockingWriteAndFlush(p[:n]); res.IsErr() {
+			w.err = fmt.Errorf("httpserver: failed to write response body: %v", res.UnwrapErr())
+			return written, w.err
+		}
+		written += n
+		p = p[n:]
+	}
+	return written, nil
+}
+
+// start builds the outgoing-response from the headers/status accumulated so far, hands it to the
+// response-outparam, and opens the body stream that subsequent Write calls flush into.
+func (w *responseWriter) start() {
+	var tuples []echo.WasiHttp0_2_0_TypesTuple2FieldKeyFieldValueT
+	for key, values := range w.header {
+		for _, v := range values {
+			tuples = append(tuples, echo.WasiHttp0_2_0_TypesTuple2FieldKeyFieldValueT{F0: key, F1: []byte(v)})
+		}
+	}
+	headers := echo.StaticFieldsFromList(tuples).Unwrap()
+
+	resp := echo.NewOutgoingResponse(headers)
+	resp.SetStatusCode(uint16(w.status))
+
+	maybeBody := resp.Body()
+	if maybeBody.IsErr() {
+		w.err = errors.New("httpserver: failed to get outgoing response body")
+		return
+	}
+	w.body = maybeBody.Unwrap()
+
+	maybeStream := w.body.Write()
+	if maybeStream.IsErr() {
+		w.err = errors.New("httpserver: failed to open outgoing response stream")
+		return
+	}
+	w.stream = maybeStream.Unwrap()
+
+	echo.StaticResponseOutparamSet(w.out, echo.Ok[echo.WasiHttp0_2_0_TypesOutgoingResponse, echo.WasiHttp0_2_0_TypesErrorCode](resp))
+}
+
+// finish flushes any still-unsent headers (for handlers that never call Write) and closes out
+// the outgoing-body, so the response is always terminated even if the handler wrote nothing.
+func (w *responseWriter) finish() {
+	if !w.headerWritten {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.err != nil {
+		return
+	}
+
+	w.stream.Drop()
+	echo.StaticOutgoingBodyFinish(w.body, echo.None[echo.WasiHttp0_2_0_TypesTrailers]())
+}
+
+// writeError reports a handler construction failure (e.g. a malformed path) directly on the
+// response-outparam, since no responseWriter exists yet to carry it.
+func writeError(out echo.WasiHttp0_2_0_TypesResponseOutparam, err error) {
+	headers := echo.StaticFieldsFromList([]echo.WasiHttp0_2_0_TypesTuple2FieldKeyFieldValueT{
+		{F0: "Content-Type", F1: []byte("text/plain")},
+	}).Unwrap()
+
+	resp := echo.NewOutgoingResponse(headers)
+	resp.SetStatusCode(http.StatusInternalServerError)
+
+	maybeBody := resp.Body()
+	if maybeBody.IsErr() {
+		return
+	}
+	body := maybeBody.Unwrap()
+
+	maybeStream := body.Write()
+	if maybeStream.IsErr() {
+		return
+	}
+	stream := maybeStream.Unwrap()
+
+	stream.BlockingWriteAndFlush([]byte(err.Error()))
+	stream.Drop()
+	echo.StaticOutgoingBodyFinish(body, echo.None[echo.WasiHttp0_2_0_TypesTrailers]())
+
+	echo.StaticResponseOutparamSet(out, echo.Ok[echo.WasiHttp0_2_0_TypesOutgoingResponse, echo.WasiHttp0_2_0_TypesErrorCode](resp))
+}
@@ -20,12 +20,15 @@ import (
 	"io/ioutil"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	nats "github.com/nats-io/nats.go"
 	msgpack "github.com/vmihailenco/msgpack/v5"
+	yaml "gopkg.in/yaml.v3"
 )
 
 type LinkDefinition struct {
@@ -90,18 +93,44 @@ var (
 	linkDefs      map[string]LinkDefinition
 )
 
-func main() {
-
-	hostDataRaw := os.Getenv("WASMCLOUD_HOST_DATA")
+// loadHostData reads the provider's host data. If WASMCLOUD_HOST_DATA_FILE points at a file on
+// disk, its contents are decoded as JSON or YAML based on the file extension; otherwise host
+// data is read from the legacy WASMCLOUD_HOST_DATA env var as base64-encoded JSON.
+func loadHostData() (HostData, error) {
 	var hostData HostData
-	hostDataDecoded, err := base64.StdEncoding.DecodeString(hostDataRaw)
+
+	if path := os.Getenv("WASMCLOUD_HOST_DATA_FILE"); path != "" {
+		raw, err := ioutil.ReadFile(path)
+		if err != nil {
+			return hostData, fmt.Errorf("failed to read WASMCLOUD_HOST_DATA_FILE: %w", err)
+		}
+		switch strings.ToLower(filepath.Ext(path)) {
+		case ".yaml", ".yml":
+			err = yaml.Unmarshal(raw, &hostData)
+		default:
+			err = json.Unmarshal(raw, &hostData)
+		}
+		if err != nil {
+			return hostData, fmt.Errorf("failed to decode WASMCLOUD_HOST_DATA_FILE: %w", err)
+		}
+		return hostData, nil
+	}
+
+	hostDataDecoded, err := base64.StdEncoding.DecodeString(os.Getenv("WASMCLOUD_HOST_DATA"))
 	if err != nil {
-		fmt.Printf("Unable to decode base64, %s", err)
-		return
+		return hostData, fmt.Errorf("unable to decode base64: %w", err)
+	}
+	if err := json.Unmarshal(hostDataDecoded, &hostData); err != nil {
+		return hostData, fmt.Errorf("bad host data: %w", err)
 	}
-	err = json.Unmarshal([]byte(hostDataDecoded), &hostData)
+	return hostData, nil
+}
+
+func main() {
+
+	hostData, err := loadHostData()
 	if err != nil {
-		fmt.Printf("Bad environment variables, %s", err)
+		fmt.Printf("Failed to load host data, %s", err)
 		return
 	}
 
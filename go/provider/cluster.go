@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ClusterMode controls which peer(s) in a Cluster fire a provider's link lifecycle callbacks for
+// a given link.
+type ClusterMode int
+
+const (
+	// ClusterModeLeaderOnly runs every link lifecycle callback only on the elected leader. This is
+	// the default: it's the right choice for providers holding connections or in-memory state that
+	// would be wasteful, or actively wrong, to duplicate across every peer.
+	ClusterModeLeaderOnly ClusterMode = iota
+	// ClusterModeSharded assigns each link to exactly one peer via a consistent hash over
+	// SourceID+Target (see clusterHashRing), rebuilt whenever the peer set changes, and only that
+	// peer's callback fires. Links spread across every peer rather than concentrating on the
+	// leader, at the cost of each peer needing to handle whichever links the ring assigns it.
+	ClusterModeSharded
+	// ClusterModeEveryPeer runs every link lifecycle callback on every peer unconditionally: the
+	// "run everywhere" opt-in for stateless providers that don't need link coordination at all,
+	// just membership/leader visibility via Peers() and OnBecomeLeader.
+	ClusterModeEveryPeer
+)
+
+// ClusterOption configures a Cluster constructed with NewCluster.
+type ClusterOption func(*Cluster)
+
+// WithClusterMode overrides the default ClusterModeLeaderOnly.
+func WithClusterMode(mode ClusterMode) ClusterOption {
+	return func(c *Cluster) { c.mode = mode }
+}
+
+// WithClusterLeaseTTL overrides how long a claimed leader or peer-membership lease is valid
+// before another peer may treat it as abandoned (default 15s). Cluster renews its own leases at
+// ttl/3; a TTL shorter than a few renew intervals risks flapping under ordinary network jitter.
+func WithClusterLeaseTTL(ttl time.Duration) ClusterOption {
+	return func(c *Cluster) { c.leaseTTL = ttl }
+}
+
+// Cluster coordinates every running instance of one provider (same HostData.ProviderKey)
+// connected to a lattice: it elects a single leader over ClusterKV, gossips peer membership
+// through the same store, and (see ClusterMode) decides which peer's link lifecycle callbacks
+// actually run for a given link. Construct one with NewCluster and enable it on a
+// WasmcloudProvider with WithCluster, or use WithClustering to have one built automatically over
+// JetStream KV.
+//
+// A Cluster's ClusterKV keys are namespaced under its providerID, so multiple providers can
+// safely share one bucket.
+type Cluster struct {
+	providerID string
+	instanceID string
+	kv         ClusterKV
+	mode       ClusterMode
+
+	leaseTTL      time.Duration
+	renewInterval time.Duration
+
+	mu       sync.Mutex
+	isLeader bool
+	peers    map[string]time.Time // instanceID -> lease deadline
+	ring     *clusterHashRing
+	// ringPeers is the sorted peer list ring was last built from.
+	ringPeers []string
+
+	onBecomeLeader []func(context.Context)
+	onResignLeader []func(context.Context)
+	onRebalance    []func(peers []string)
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// NewCluster constructs a Cluster for providerID (HostData.ProviderKey), identifying this running
+// instance as instanceID (HostData.InstanceID, already unique per instance). Call Start to begin
+// participating in election and gossip.
+func NewCluster(kv ClusterKV, providerID, instanceID string, opts ...ClusterOption) *Cluster {
+	c := &Cluster{
+		providerID: providerID,
+		instanceID: instanceID,
+		kv:         kv,
+		leaseTTL:   15 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.renewInterval == 0 {
+		c.renewInterval = c.leaseTTL / 3
+	}
+	c.peers = map[string]time.Time{instanceID: time.Now().Add(c.leaseTTL)}
+	c.ringPeers = c.peerIDsLocked()
+	c.ring = newClusterHashRing(c.ringPeers)
+	return c
+}
+
+// OnBecomeLeader registers fn to run whenever this instance is elected leader.
+func (c *Cluster) OnBecomeLeader(fn func(context.Context)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onBecomeLeader = append(c.onBecomeLeader, fn)
+}
+
+// OnResignLeader registers fn to run whenever this instance steps down as leader, whether
+// voluntarily (Close) or because it lost its lease.
+func (c *Cluster) OnResignLeader(fn func(context.Context)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onResignLeader = append(c.onResignLeader, fn)
+}
+
+// OnRebalance registers fn to run with the current sorted peer list whenever the peer set
+// changes: a peer's lease is first observed, expires, or is removed.
+func (c *Cluster) OnRebalance(fn func(peers []string)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onRebalance = append(c.onRebalance, fn)
+}
+
+// IsLeader reports whether this instance currently holds the leader lease.
+func (c *Cluster) IsLeader() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.isLeader
+}
+
+// Peers returns the instance IDs of every peer currently believed alive (including this
+// instance), sorted.
+func (c *Cluster) Peers() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.peerIDsLocked()
+}
+
+// ShouldHandleLink reports whether this instance's link lifecycle callback should fire for l,
+// according to Mode: always for ClusterModeEveryPeer, only the leader for the default
+// ClusterModeLeaderOnly, or only whichever peer the consistent hash ring currently assigns l to
+// for ClusterModeSharded.
+func (c *Cluster) ShouldHandleLink(l InterfaceLinkDefinition) bool {
+	switch c.mode {
+	case ClusterModeEveryPeer:
+		return true
+	case ClusterModeSharded:
+		c.mu.Lock()
+		owner := c.ring.owner(clusterLinkKey(l))
+		c.mu.Unlock()
+		return owner == c.instanceID
+	default:
+		return c.IsLeader()
+	}
+}
+
+// Start begins this instance's election and gossip loop, running until ctx is cancelled or Close
+// is called.
+func (c *Cluster) Start(ctx context.Context) error {
+	runCtx, cancel := context.WithCancel(ctx)
+	c.cancel = cancel
+
+	c.wg.Add(1)
+	go c.run(runCtx)
+	return nil
+}
+
+// Close stops this instance's election and gossip loop, resigning leadership and removing this
+// instance's peer entry so other peers don't wait out its lease before reacting.
+func (c *Cluster) Close() error {
+	if c.cancel != nil {
+		c.cancel()
+	}
+	c.wg.Wait()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if c.IsLeader() {
+		_ = c.kv.Delete(ctx, c.leaderKey())
+		c.resign(ctx)
+	}
+	return c.kv.Delete(ctx, c.peerKey(c.instanceID))
+}
+
+func (c *Cluster) run(ctx context.Context) {
+	defer c.wg.Done()
+
+	events, err := c.kv.Watch(ctx, c.providerID+".peers")
+	if err != nil {
+		events = nil
+	}
+
+	ticker := time.NewTicker(c.renewInterval)
+	defer ticker.Stop()
+
+	c.tick(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.tick(ctx)
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+				continue
+			}
+			c.handlePeerEvent(event)
+		}
+	}
+}
+
+func (c *Cluster) tick(ctx context.Context) {
+	c.renewOwnPeerLease(ctx)
+	c.runElection(ctx)
+	c.reapExpiredPeers(time.Now())
+}
+
+func (c *Cluster) renewOwnPeerLease(ctx context.Context) {
+	key := c.peerKey(c.instanceID)
+	deadline := time.Now().Add(c.leaseTTL)
+	value := []byte(deadline.Format(time.RFC3339Nano))
+
+	_, revision, err := c.kv.Get(ctx, key)
+	switch {
+	case errors.Is(err, ErrClusterKeyNotFound):
+		_, err = c.kv.Create(ctx, key, value)
+	case err == nil:
+		_, err = c.kv.Update(ctx, key, value, revision)
+	}
+	if err != nil {
+		// Another writer raced us (e.g. an instanceID collision); retry next tick.
+		return
+	}
+
+	c.mu.Lock()
+	c.peers[c.instanceID] = deadline
+	c.mu.Unlock()
+	c.rebalance()
+}
+
+func (c *Cluster) runElection(ctx context.Context) {
+	key := c.leaderKey()
+	now := time.Now()
+
+	if c.IsLeader() {
+		value, revision, err := c.kv.Get(ctx, key)
+		var lease clusterLeaderLease
+		if err != nil || json.Unmarshal(value, &lease) != nil || lease.InstanceID != c.instanceID {
+			c.resign(ctx)
+			return
+		}
+		lease.Deadline = now.Add(c.leaseTTL)
+		encoded, _ := json.Marshal(lease)
+		if _, err := c.kv.Update(ctx, key, encoded, revision); err != nil {
+			c.resign(ctx)
+		}
+		return
+	}
+
+	lease := clusterLeaderLease{InstanceID: c.instanceID, Deadline: now.Add(c.leaseTTL)}
+	encoded, _ := json.Marshal(lease)
+
+	value, revision, err := c.kv.Get(ctx, key)
+	switch {
+	case errors.Is(err, ErrClusterKeyNotFound):
+		if _, err := c.kv.Create(ctx, key, encoded); err == nil {
+			c.becomeLeader(ctx)
+		}
+	case err == nil:
+		var current clusterLeaderLease
+		if json.Unmarshal(value, &current) != nil || now.After(current.Deadline) {
+			if _, err := c.kv.Update(ctx, key, encoded, revision); err == nil {
+				c.becomeLeader(ctx)
+			}
+		}
+	}
+}
+
+func (c *Cluster) becomeLeader(ctx context.Context) {
+	c.mu.Lock()
+	c.isLeader = true
+	hooks := append([]func(context.Context){}, c.onBecomeLeader...)
+	c.mu.Unlock()
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+}
+
+func (c *Cluster) resign(ctx context.Context) {
+	c.mu.Lock()
+	if !c.isLeader {
+		c.mu.Unlock()
+		return
+	}
+	c.isLeader = false
+	hooks := append([]func(context.Context){}, c.onResignLeader...)
+	c.mu.Unlock()
+	for _, hook := range hooks {
+		hook(ctx)
+	}
+}
+
+func (c *Cluster) handlePeerEvent(event ClusterKVEvent) {
+	instanceID, ok := c.parsePeerKey(event.Key)
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	if event.Deleted {
+		delete(c.peers, instanceID)
+	} else if deadline, err := time.Parse(time.RFC3339Nano, string(event.Value)); err == nil {
+		c.peers[instanceID] = deadline
+	}
+	c.mu.Unlock()
+	c.rebalance()
+}
+
+func (c *Cluster) reapExpiredPeers(now time.Time) {
+	c.mu.Lock()
+	changed := false
+	for id, deadline := range c.peers {
+		if id != c.instanceID && now.After(deadline) {
+			delete(c.peers, id)
+			changed = true
+		}
+	}
+	c.mu.Unlock()
+	if changed {
+		c.rebalance()
+	}
+}
+
+func (c *Cluster) rebalance() {
+	c.mu.Lock()
+	peers := c.peerIDsLocked()
+	if equalPeerIDs(peers, c.ringPeers) {
+		c.mu.Unlock()
+		return
+	}
+	c.ringPeers = peers
+	c.ring = newClusterHashRing(peers)
+	hooks := append([]func([]string){}, c.onRebalance...)
+	c.mu.Unlock()
+	for _, hook := range hooks {
+		hook(peers)
+	}
+}
+
+func equalPeerIDs(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *Cluster) peerIDsLocked() []string {
+	ids := make([]string, 0, len(c.peers))
+	for id := range c.peers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (c *Cluster) leaderKey() string {
+	return c.providerID + ".leader"
+}
+
+func (c *Cluster) peerKey(instanceID string) string {
+	return c.providerID + ".peers." + instanceID
+}
+
+// parsePeerKey extracts the instance ID from a peer key this Cluster's own peerKey produced,
+// reporting false for anything else (e.g. the leader key, seen on the same watch if a future
+// caller widens the watched prefix).
+func (c *Cluster) parsePeerKey(key string) (string, bool) {
+	prefix := c.providerID + ".peers."
+	if len(key) <= len(prefix) || key[:len(prefix)] != prefix {
+		return "", false
+	}
+	return key[len(prefix):], true
+}
+
+// clusterLeaderLease is the JSON value stored at a Cluster's leader key.
+type clusterLeaderLease struct {
+	InstanceID string    `json:"instance_id"`
+	Deadline   time.Time `json:"deadline"`
+}
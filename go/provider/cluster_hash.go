@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"fmt"
+	"hash/crc32"
+	"sort"
+)
+
+// clusterHashVirtualNodes is how many points on the ring each peer gets, so that a small peer set
+// still spreads link ownership roughly evenly rather than landing everything on whichever peer
+// happens to hash lowest.
+const clusterHashVirtualNodes = 64
+
+// clusterHashRing is a consistent-hash ring over a peer set, used to decide which peer owns a
+// given link when a Cluster is running in ClusterModeSharded. It's rebuilt from scratch (see
+// newClusterHashRing) every time Cluster's peer set changes, rather than updated incrementally:
+// peer sets are small and rebalances are already rare events.
+type clusterHashRing struct {
+	points []clusterHashPoint
+}
+
+type clusterHashPoint struct {
+	hash uint32
+	peer string
+}
+
+func newClusterHashRing(peers []string) *clusterHashRing {
+	points := make([]clusterHashPoint, 0, len(peers)*clusterHashVirtualNodes)
+	for _, peer := range peers {
+		for v := 0; v < clusterHashVirtualNodes; v++ {
+			points = append(points, clusterHashPoint{
+				hash: crc32.ChecksumIEEE([]byte(fmt.Sprintf("%s#%d", peer, v))),
+				peer: peer,
+			})
+		}
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].hash < points[j].hash })
+	return &clusterHashRing{points: points}
+}
+
+// owner returns the peer key maps to, or "" if the ring has no peers.
+func (r *clusterHashRing) owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+	hash := crc32.ChecksumIEEE([]byte(key))
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= hash })
+	if i == len(r.points) {
+		i = 0
+	}
+	return r.points[i].peer
+}
+
+// clusterLinkKey is the key a link's ownership is hashed on: the pair that makes a link unique
+// for a given provider, regardless of which side (source or target) it's on.
+func clusterLinkKey(l InterfaceLinkDefinition) string {
+	return l.SourceID + "->" + l.Target
+}
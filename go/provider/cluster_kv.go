@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// Sentinel errors ClusterKV implementations return for the conditions Cluster's election and
+// gossip loops specifically branch on.
+var (
+	// ErrClusterKeyExists is returned by Create when key is already present.
+	ErrClusterKeyExists = errors.New("cluster kv: key already exists")
+	// ErrClusterRevisionMismatch is returned by Update when expectedRevision is stale.
+	ErrClusterRevisionMismatch = errors.New("cluster kv: revision mismatch")
+	// ErrClusterKeyNotFound is returned by Get when key is absent.
+	ErrClusterKeyNotFound = errors.New("cluster kv: key not found")
+)
+
+// ClusterKVEvent is one change Watch reports: key was created or updated to Value, or (Deleted)
+// removed.
+type ClusterKVEvent struct {
+	Key     string
+	Value   []byte
+	Deleted bool
+}
+
+// ClusterKV is the key-value contract Cluster needs for leader election and peer gossip:
+// create-if-absent, compare-and-swap update, get, delete, and a prefix watch. NatsClusterKV
+// implements it over a NATS JetStream KV bucket, the default WithClustering configures; supply a
+// different implementation (e.g. one backed by Consul or etcd) to NewCluster to use something
+// else instead.
+type ClusterKV interface {
+	Create(ctx context.Context, key string, value []byte) (revision uint64, err error)
+	Update(ctx context.Context, key string, value []byte, expectedRevision uint64) (revision uint64, err error)
+	Get(ctx context.Context, key string) (value []byte, revision uint64, err error)
+	Delete(ctx context.Context, key string) error
+	// Watch streams every create/update/delete for a key under prefix until ctx is cancelled, at
+	// which point it closes the returned channel.
+	Watch(ctx context.Context, prefix string) (<-chan ClusterKVEvent, error)
+}
+
+// NatsClusterKV adapts a jetstream.KeyValue bucket to ClusterKV.
+type NatsClusterKV struct {
+	kv jetstream.KeyValue
+}
+
+// NewNatsClusterKV wraps kv (e.g. created with jetstream.CreateOrUpdateKeyValue) as a ClusterKV.
+func NewNatsClusterKV(kv jetstream.KeyValue) *NatsClusterKV {
+	return &NatsClusterKV{kv: kv}
+}
+
+func (n *NatsClusterKV) Create(ctx context.Context, key string, value []byte) (uint64, error) {
+	rev, err := n.kv.Create(ctx, key, value)
+	if errors.Is(err, jetstream.ErrKeyExists) {
+		return 0, ErrClusterKeyExists
+	}
+	return rev, err
+}
+
+func (n *NatsClusterKV) Update(ctx context.Context, key string, value []byte, expectedRevision uint64) (uint64, error) {
+	rev, err := n.kv.Update(ctx, key, value, expectedRevision)
+	if err != nil {
+		// jetstream.Update reports both "doesn't exist" and "wrong revision" as a wrapped
+		// ErrKeyExists-adjacent API error; since Cluster always calls Update on a key it just Got
+		// the revision for, any failure here means another peer raced it.
+		return 0, fmt.Errorf("%w: %w", ErrClusterRevisionMismatch, err)
+	}
+	return rev, nil
+}
+
+func (n *NatsClusterKV) Get(ctx context.Context, key string) ([]byte, uint64, error) {
+	entry, err := n.kv.Get(ctx, key)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil, 0, ErrClusterKeyNotFound
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+	return entry.Value(), entry.Revision(), nil
+}
+
+func (n *NatsClusterKV) Delete(ctx context.Context, key string) error {
+	err := n.kv.Delete(ctx, key)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil
+	}
+	return err
+}
+
+func (n *NatsClusterKV) Watch(ctx context.Context, prefix string) (<-chan ClusterKVEvent, error) {
+	watcher, err := n.kv.Watch(ctx, prefix+".>")
+	if err != nil {
+		return nil, fmt.Errorf("failed to watch cluster kv prefix %q: %w", prefix, err)
+	}
+
+	events := make(chan ClusterKVEvent)
+	go func() {
+		defer close(events)
+		defer watcher.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case entry, ok := <-watcher.Updates():
+				if !ok {
+					return
+				}
+				if entry == nil {
+					// nil marks the end of the initial state-of-the-world batch.
+					continue
+				}
+				event := ClusterKVEvent{Key: entry.Key(), Value: entry.Value(), Deleted: entry.Operation() == jetstream.KeyValueDelete || entry.Operation() == jetstream.KeyValuePurge}
+				select {
+				case events <- event:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+	return events, nil
+}
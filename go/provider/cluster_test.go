@@ -0,0 +1,209 @@
+package provider
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClusterKV is an in-memory ClusterKV, used instead of a real JetStream bucket (and so an
+// embedded NATS server, which this repo doesn't otherwise carry as a test dependency) to exercise
+// Cluster's election and gossip logic directly.
+type fakeClusterKV struct {
+	mu      sync.Mutex
+	data    map[string][]byte
+	rev     map[string]uint64
+	nextRev uint64
+	subs    []chan ClusterKVEvent
+}
+
+func newFakeClusterKV() *fakeClusterKV {
+	return &fakeClusterKV{data: map[string][]byte{}, rev: map[string]uint64{}}
+}
+
+func (f *fakeClusterKV) Create(_ context.Context, key string, value []byte) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.data[key]; ok {
+		return 0, ErrClusterKeyExists
+	}
+	f.nextRev++
+	f.data[key] = value
+	f.rev[key] = f.nextRev
+	f.notifyLocked(ClusterKVEvent{Key: key, Value: value})
+	return f.nextRev, nil
+}
+
+func (f *fakeClusterKV) Update(_ context.Context, key string, value []byte, expectedRevision uint64) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.rev[key] != expectedRevision {
+		return 0, ErrClusterRevisionMismatch
+	}
+	f.nextRev++
+	f.data[key] = value
+	f.rev[key] = f.nextRev
+	f.notifyLocked(ClusterKVEvent{Key: key, Value: value})
+	return f.nextRev, nil
+}
+
+func (f *fakeClusterKV) Get(_ context.Context, key string) ([]byte, uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	value, ok := f.data[key]
+	if !ok {
+		return nil, 0, ErrClusterKeyNotFound
+	}
+	return value, f.rev[key], nil
+}
+
+func (f *fakeClusterKV) Delete(_ context.Context, key string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	delete(f.data, key)
+	delete(f.rev, key)
+	f.notifyLocked(ClusterKVEvent{Key: key, Deleted: true})
+	return nil
+}
+
+func (f *fakeClusterKV) Watch(_ context.Context, _ string) (<-chan ClusterKVEvent, error) {
+	ch := make(chan ClusterKVEvent, 16)
+	f.mu.Lock()
+	f.subs = append(f.subs, ch)
+	f.mu.Unlock()
+	return ch, nil
+}
+
+// notifyLocked broadcasts event to every watcher; callers hold f.mu. Doesn't filter by prefix,
+// since every test here only ever has one Cluster's watch per providerID.
+func (f *fakeClusterKV) notifyLocked(event ClusterKVEvent) {
+	for _, ch := range f.subs {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+func waitUntil(t *testing.T, timeout time.Duration, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatalf("condition not met within %s", timeout)
+}
+
+func TestClusterSingleInstanceBecomesLeader(t *testing.T) {
+	kv := newFakeClusterKV()
+	cluster := NewCluster(kv, "test-provider", "instance-1", WithClusterLeaseTTL(60*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := cluster.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer cluster.Close()
+
+	waitUntil(t, time.Second, cluster.IsLeader)
+
+	if peers := cluster.Peers(); len(peers) != 1 || peers[0] != "instance-1" {
+		t.Errorf("expected Peers() == [instance-1], got %v", peers)
+	}
+}
+
+func TestClusterOnlyOneOfTwoInstancesBecomesLeader(t *testing.T) {
+	kv := newFakeClusterKV()
+	a := NewCluster(kv, "test-provider", "instance-a", WithClusterLeaseTTL(60*time.Millisecond))
+	b := NewCluster(kv, "test-provider", "instance-b", WithClusterLeaseTTL(60*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if err := a.Start(ctx); err != nil {
+		t.Fatalf("a.Start: %v", err)
+	}
+	if err := b.Start(ctx); err != nil {
+		t.Fatalf("b.Start: %v", err)
+	}
+	defer a.Close()
+	defer b.Close()
+
+	waitUntil(t, time.Second, func() bool { return a.IsLeader() || b.IsLeader() })
+
+	if a.IsLeader() && b.IsLeader() {
+		t.Fatal("expected at most one instance to be leader")
+	}
+
+	waitUntil(t, time.Second, func() bool { return len(a.Peers()) == 2 && len(b.Peers()) == 2 })
+}
+
+func TestClusterResignsLeadershipOnClose(t *testing.T) {
+	kv := newFakeClusterKV()
+	a := NewCluster(kv, "test-provider", "instance-a", WithClusterLeaseTTL(60*time.Millisecond))
+	b := NewCluster(kv, "test-provider", "instance-b", WithClusterLeaseTTL(60*time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	_ = a.Start(ctx)
+	_ = b.Start(ctx)
+	defer b.Close()
+
+	waitUntil(t, time.Second, func() bool { return a.IsLeader() || b.IsLeader() })
+
+	if a.IsLeader() {
+		if err := a.Close(); err != nil {
+			t.Fatalf("a.Close: %v", err)
+		}
+		waitUntil(t, time.Second, b.IsLeader)
+	} else {
+		if err := b.Close(); err != nil {
+			t.Fatalf("b.Close: %v", err)
+		}
+		waitUntil(t, time.Second, a.IsLeader)
+	}
+}
+
+func TestShouldHandleLinkModes(t *testing.T) {
+	link := InterfaceLinkDefinition{SourceID: "component-a", Target: "this-provider"}
+
+	leaderOnly := NewCluster(newFakeClusterKV(), "p", "instance-1")
+	if leaderOnly.ShouldHandleLink(link) {
+		t.Error("expected ClusterModeLeaderOnly to refuse a link before becoming leader")
+	}
+	leaderOnly.becomeLeader(context.Background())
+	if !leaderOnly.ShouldHandleLink(link) {
+		t.Error("expected ClusterModeLeaderOnly to handle a link once leader")
+	}
+
+	everyPeer := NewCluster(newFakeClusterKV(), "p", "instance-1", WithClusterMode(ClusterModeEveryPeer))
+	if !everyPeer.ShouldHandleLink(link) {
+		t.Error("expected ClusterModeEveryPeer to always handle a link")
+	}
+}
+
+func TestClusterHashRingIsDeterministicAndCoversEveryPeer(t *testing.T) {
+	peers := []string{"instance-1", "instance-2", "instance-3"}
+	ring := newClusterHashRing(peers)
+
+	owners := map[string]bool{}
+	for i := 0; i < 300; i++ {
+		link := InterfaceLinkDefinition{SourceID: "component", Target: "target"}
+		key := clusterLinkKey(link) + string(rune('a'+i%26))
+		owner := ring.owner(key)
+		if owner == "" {
+			t.Fatalf("expected a non-empty owner for key %q", key)
+		}
+		if ring.owner(key) != owner {
+			t.Fatalf("expected owner(%q) to be deterministic", key)
+		}
+		owners[owner] = true
+	}
+
+	if len(owners) != len(peers) {
+		t.Errorf("expected all %d peers to own at least one key, got owners %v", len(peers), owners)
+	}
+}
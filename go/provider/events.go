@@ -0,0 +1,275 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// eventQueueSize bounds how many in-flight events a single subscriber can hold for one Event
+// Source before Publish blocks, giving the bus backpressure without buffering unboundedly.
+const eventQueueSize = 32
+
+// EventKind identifies the lifecycle event a subscriber registers for via EventBus.Subscribe. See
+// LinkPutEvent, LinkDelEvent, HealthCheckEvent, and ShutdownEvent for the payload each carries.
+type EventKind int
+
+const (
+	EventLinkPut EventKind = iota
+	EventLinkDel
+	EventHealthCheck
+	EventShutdown
+)
+
+func (k EventKind) String() string {
+	switch k {
+	case EventLinkPut:
+		return "link_put"
+	case EventLinkDel:
+		return "link_del"
+	case EventHealthCheck:
+		return "health_check"
+	case EventShutdown:
+		return "shutdown"
+	default:
+		return fmt.Sprintf("EventKind(%d)", int(k))
+	}
+}
+
+// Event is published onto an EventBus topic. Source identifies the component a given event is
+// about (a link's SourceID, for link events); EventBus uses it to guarantee ordered delivery
+// per-source to a given subscriber while still running different sources concurrently. Events
+// with no natural source (HealthCheckEvent, ShutdownEvent) return "".
+type Event interface {
+	Kind() EventKind
+	Source() string
+}
+
+// LinkPutEvent is published when a link put message passes policy and is about to be applied,
+// before wp.putLink invokes the user's SourceLinkPut/TargetLinkPut handler.
+type LinkPutEvent struct {
+	Link InterfaceLinkDefinition
+}
+
+func (e LinkPutEvent) Kind() EventKind { return EventLinkPut }
+func (e LinkPutEvent) Source() string  { return e.Link.SourceID }
+
+// LinkDelEvent is published when a link delete message passes policy and is about to be applied,
+// before wp.deleteLink invokes the user's SourceLinkDel/TargetLinkDel handler.
+type LinkDelEvent struct {
+	Link InterfaceLinkDefinition
+}
+
+func (e LinkDelEvent) Kind() EventKind { return EventLinkDel }
+func (e LinkDelEvent) Source() string  { return e.Link.SourceID }
+
+// HealthCheckEvent is published after the provider has already answered a LATTICE_HEALTH request,
+// reporting the message it answered with. The reply itself is sent synchronously from subToNats,
+// since NATS requests expect a timely response; this event exists for observers (audit logging,
+// external metrics sinks) that want to react to health checks without being on the reply's
+// critical path.
+type HealthCheckEvent struct {
+	Message string
+}
+
+func (e HealthCheckEvent) Kind() EventKind { return EventHealthCheck }
+func (e HealthCheckEvent) Source() string  { return "" }
+
+// ShutdownEvent is published after the provider has run the user's shutdownFunc in response to a
+// LATTICE_SHUTDOWN message, for the same reason HealthCheckEvent is published after the fact: the
+// NATS request's reply can't wait on arbitrary subscriber work.
+type ShutdownEvent struct{}
+
+func (e ShutdownEvent) Kind() EventKind { return EventShutdown }
+func (e ShutdownEvent) Source() string  { return "" }
+
+// EventHandler processes a single Event delivered by an EventBus subscription.
+type EventHandler func(context.Context, Event) error
+
+// EventMiddleware wraps an EventHandler, e.g. to start a tracing span, record a metric, or
+// recover a panic around every event a subscriber receives. Register one with EventBus.Use.
+type EventMiddleware func(EventHandler) EventHandler
+
+// EventBus decouples subToNats from the handlers that act on link put/del, health check, and
+// shutdown messages: instead of calling putSourceLinkFunc and friends directly, subToNats
+// publishes a typed Event, and one or more subscribers (the default ones wired up in provider.New,
+// plus any a provider author adds via Events().Subscribe) react to it. This lets user code add
+// audit logging, external metrics sinks, or integration tests around link/health/shutdown
+// lifecycle without monkey-patching NATS subscription callbacks.
+type EventBus struct {
+	logger *slog.Logger
+
+	mu          sync.Mutex
+	middleware  []EventMiddleware
+	subscribers map[EventKind][]*eventSubscriber
+}
+
+func newEventBus(logger *slog.Logger) *EventBus {
+	return &EventBus{logger: logger, subscribers: make(map[EventKind][]*eventSubscriber)}
+}
+
+// Use registers mw to wrap every handler subscribed after this call. It has no effect on
+// subscribers already registered, so call it before Subscribe, e.g. ahead of the default
+// subscribers provider.New wires up.
+func (b *EventBus) Use(mw ...EventMiddleware) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.middleware = append(b.middleware, mw...)
+}
+
+// Subscribe registers handler, wrapped in every middleware passed to Use so far, to run for every
+// event of kind that Publish sees. Multiple subscribers per kind run independently; each gets its
+// own per-source ordered, backpressured queue (see eventSubscriber). The returned func
+// unsubscribes handler; it's safe to call at most once.
+func (b *EventBus) Subscribe(kind EventKind, handler EventHandler) func() {
+	b.mu.Lock()
+	wrapped := handler
+	for i := len(b.middleware) - 1; i >= 0; i-- {
+		wrapped = b.middleware[i](wrapped)
+	}
+	sub := newEventSubscriber(wrapped, b.logger)
+	b.subscribers[kind] = append(b.subscribers[kind], sub)
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		subs := b.subscribers[kind]
+		for i, s := range subs {
+			if s == sub {
+				b.subscribers[kind] = append(subs[:i:i], subs[i+1:]...)
+				break
+			}
+		}
+	}
+}
+
+// Publish hands event to every subscriber registered for event.Kind(). It returns as soon as
+// event is queued with each subscriber, not when every subscriber has finished handling it; see
+// eventSubscriber for the ordering and backpressure guarantees that queueing gives.
+func (b *EventBus) Publish(ctx context.Context, event Event) {
+	b.mu.Lock()
+	subs := append([]*eventSubscriber{}, b.subscribers[event.Kind()]...)
+	b.mu.Unlock()
+
+	for _, sub := range subs {
+		sub.publish(ctx, event)
+	}
+}
+
+// eventSubscriber runs one handler against every event delivered to it, maintaining a dedicated
+// queue per Event.Source so that events about the same source are handled in publish order, while
+// distinct sources are handled concurrently. publish blocks once a source's queue is full
+// (eventQueueSize), applying backpressure to the publisher instead of buffering unboundedly, or
+// returns early if ctx is cancelled first.
+type eventSubscriber struct {
+	handler EventHandler
+	logger  *slog.Logger
+
+	mu     sync.Mutex
+	queues map[string]chan queuedEvent
+}
+
+type queuedEvent struct {
+	ctx   context.Context
+	event Event
+}
+
+func newEventSubscriber(handler EventHandler, logger *slog.Logger) *eventSubscriber {
+	return &eventSubscriber{handler: handler, logger: logger, queues: make(map[string]chan queuedEvent)}
+}
+
+func (s *eventSubscriber) publish(ctx context.Context, event Event) {
+	s.mu.Lock()
+	q, ok := s.queues[event.Source()]
+	if !ok {
+		q = make(chan queuedEvent, eventQueueSize)
+		s.queues[event.Source()] = q
+		go s.drain(q)
+	}
+	s.mu.Unlock()
+
+	select {
+	case q <- queuedEvent{ctx: ctx, event: event}:
+	case <-ctx.Done():
+	}
+}
+
+func (s *eventSubscriber) drain(q chan queuedEvent) {
+	for qe := range q {
+		if err := s.handler(qe.ctx, qe.event); err != nil {
+			s.logger.Error("event subscriber failed", "kind", qe.event.Kind().String(), "error", err)
+		}
+	}
+}
+
+// Events returns the provider's EventBus, so provider authors can add their own subscribers
+// (audit logging, external metrics sinks, integration tests) alongside the default ones
+// provider.New registers for LinkPutEvent and LinkDelEvent. HealthCheckEvent and ShutdownEvent
+// have no default subscriber.
+func (wp *WasmcloudProvider) Events() *EventBus {
+	return wp.events
+}
+
+// registerDefaultEventSubscribers wires the provider's existing link behavior (putLink,
+// deleteLink) as the default EventBus subscribers, so subToNats publishing link events instead of
+// calling those directly is a no-op change in behavior until a provider author adds subscribers
+// of their own via Events().Subscribe. The user's healthMsgFunc and shutdownFunc are not
+// subscribers: subToNats calls them directly and publishes HealthCheckEvent/ShutdownEvent after.
+func (wp *WasmcloudProvider) registerDefaultEventSubscribers() {
+	wp.events.Subscribe(EventLinkPut, func(ctx context.Context, event Event) error {
+		e := event.(LinkPutEvent)
+		return wp.putLink(ctx, e.Link)
+	})
+	wp.events.Subscribe(EventLinkDel, func(ctx context.Context, event Event) error {
+		e := event.(LinkDelEvent)
+		return wp.deleteLink(ctx, e.Link)
+	})
+}
+
+// TracingEventMiddleware starts a span named "event.<kind>" around every event a subscriber
+// handles, as a child of the span already in ctx when Publish was called (e.g. the one
+// startSpanFromNatsMsg started for the triggering NATS message).
+func (wp *WasmcloudProvider) TracingEventMiddleware() EventMiddleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event Event) error {
+			ctx, span := wp.tracer.Start(ctx, "event."+event.Kind().String(),
+				trace.WithAttributes(attribute.String("event.source", event.Source())))
+			defer span.End()
+			return next(ctx, event)
+		}
+	}
+}
+
+// MetricsEventMiddleware records wasmcloud_provider_events_total for every delivery a subscriber
+// handles, wrapped around next so the recorded result reflects what next actually returned. It's
+// a no-op when no metrics option was configured.
+func (wp *WasmcloudProvider) MetricsEventMiddleware() EventMiddleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event Event) error {
+			err := next(ctx, event)
+			wp.recordEvent(event.Kind().String(), err)
+			return err
+		}
+	}
+}
+
+// RecoverEventMiddleware recovers a panic in next, logging it and returning an error instead of
+// crashing the subscriber's drain goroutine.
+func RecoverEventMiddleware(logger *slog.Logger) EventMiddleware {
+	return func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event Event) (err error) {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Error("event handler panicked", "kind", event.Kind().String(), "panic", r)
+					err = fmt.Errorf("event handler panicked: %v", r)
+				}
+			}()
+			return next(ctx, event)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+)
+
+func newTestEventBus() *EventBus {
+	return newEventBus(slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+func TestEventBusDeliversToMultipleSubscribers(t *testing.T) {
+	bus := newTestEventBus()
+
+	var mu sync.Mutex
+	var got []string
+
+	done1 := make(chan struct{})
+	bus.Subscribe(EventHealthCheck, func(_ context.Context, event Event) error {
+		mu.Lock()
+		got = append(got, "sub1:"+event.(HealthCheckEvent).Message)
+		mu.Unlock()
+		close(done1)
+		return nil
+	})
+	done2 := make(chan struct{})
+	bus.Subscribe(EventHealthCheck, func(_ context.Context, event Event) error {
+		mu.Lock()
+		got = append(got, "sub2:"+event.(HealthCheckEvent).Message)
+		mu.Unlock()
+		close(done2)
+		return nil
+	})
+
+	bus.Publish(context.Background(), HealthCheckEvent{Message: "healthy"})
+
+	waitOrFatal(t, done1)
+	waitOrFatal(t, done2)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(got) != 2 {
+		t.Fatalf("expected both subscribers to run, got %v", got)
+	}
+}
+
+func TestEventBusOrdersDeliveryPerSource(t *testing.T) {
+	bus := newTestEventBus()
+
+	var mu sync.Mutex
+	var order []string
+	const n = 20
+	done := make(chan struct{})
+
+	bus.Subscribe(EventLinkPut, func(_ context.Context, event Event) error {
+		e := event.(LinkPutEvent)
+		mu.Lock()
+		order = append(order, e.Link.Name)
+		if len(order) == n {
+			close(done)
+		}
+		mu.Unlock()
+		return nil
+	})
+
+	for i := 0; i < n; i++ {
+		bus.Publish(context.Background(), LinkPutEvent{Link: InterfaceLinkDefinition{
+			SourceID: "same-source",
+			Name:     string(rune('a' + i)),
+		}})
+	}
+
+	waitOrFatal(t, done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	for i := 0; i < n; i++ {
+		if order[i] != string(rune('a'+i)) {
+			t.Fatalf("events from the same source were delivered out of order: %v", order)
+		}
+	}
+}
+
+func TestEventBusUnsubscribeStopsDelivery(t *testing.T) {
+	bus := newTestEventBus()
+
+	var calls int
+	var mu sync.Mutex
+	unsubscribe := bus.Subscribe(EventShutdown, func(context.Context, Event) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return nil
+	})
+	unsubscribe()
+
+	bus.Publish(context.Background(), ShutdownEvent{})
+	time.Sleep(50 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 0 {
+		t.Fatalf("expected no delivery after unsubscribe, got %d calls", calls)
+	}
+}
+
+func TestEventBusMiddlewareWrapsSubscribers(t *testing.T) {
+	bus := newTestEventBus()
+
+	var order []string
+	var mu sync.Mutex
+	done := make(chan struct{})
+	bus.Use(func(next EventHandler) EventHandler {
+		return func(ctx context.Context, event Event) error {
+			mu.Lock()
+			order = append(order, "before")
+			mu.Unlock()
+			err := next(ctx, event)
+			mu.Lock()
+			order = append(order, "after")
+			mu.Unlock()
+			close(done)
+			return err
+		}
+	})
+
+	bus.Subscribe(EventShutdown, func(context.Context, Event) error {
+		mu.Lock()
+		order = append(order, "handle")
+		mu.Unlock()
+		return nil
+	})
+
+	bus.Publish(context.Background(), ShutdownEvent{})
+	waitOrFatal(t, done)
+
+	mu.Lock()
+	defer mu.Unlock()
+	want := []string{"before", "handle", "after"}
+	if len(order) != len(want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRecoverEventMiddlewareTurnsPanicIntoError(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	handler := RecoverEventMiddleware(logger)(func(context.Context, Event) error {
+		panic("boom")
+	})
+
+	err := handler(context.Background(), ShutdownEvent{})
+	if err == nil {
+		t.Fatal("expected a panic to be turned into an error")
+	}
+}
+
+func waitOrFatal(t *testing.T, done chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for event delivery")
+	}
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// errStaleCursor is returned by RangePaged when cursor no longer corresponds to a valid resume
+// position (e.g. the bucket changed shape between pages). ListKeys surfaces it to callers as
+// store.NewErrorOther("stale cursor"), the same as before Backend existed.
+var errStaleCursor = errors.New("stale cursor")
+
+// Backend is the storage interface Provider's wit handlers (keyvalue.go) delegate to, selected by
+// the "backend" provider config value (see newBackendFromConfig in main.go):
+//
+//   - "mem" (the default): the original sync.Map-backed store, see backend_mem.go.
+//   - "bolt": a single BoltDB file on disk, see backend_bolt.go.
+//   - "jetstream": a NATS JetStream KV bucket, see backend_jetstream.go.
+//
+// Set/Get may additionally be wrapped in a compressingBackend (compress.go) to transparently
+// zstd-compress large values; that wrapping is transparent to callers of this interface.
+type Backend interface {
+	Get(ctx context.Context, bucket, key string) ([]byte, bool, error)
+	// Set stores value under key in bucket. A positive ttl makes the entry expire after that
+	// duration, after which Get, Exists, Range, and RangePaged treat it as absent; a zero or
+	// negative ttl stores the entry with no expiry. Not every backend enforces ttl with the same
+	// precision — see each implementation's doc comment.
+	Set(ctx context.Context, bucket, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, bucket, key string) error
+	Exists(ctx context.Context, bucket, key string) (bool, error)
+	// Range calls fn with every non-expired key and value in bucket, in unspecified order,
+	// stopping early if fn returns false. It buffers at most one entry at a time, so it's safe to
+	// use for migration (see MigrateBucket) regardless of bucket size; ListKeys must use
+	// RangePaged instead, since it also needs to resume from a cursor.
+	Range(ctx context.Context, bucket string, fn func(key string, value []byte) bool) error
+	// RangePaged returns up to limit keys from bucket, resuming after the position cursor
+	// encodes (the zero cursor starts from the beginning), along with the cursor to resume from
+	// and whether more keys remain. boltBackend streams each page from a bbolt cursor; memBackend
+	// and jetstreamBackend page through a cached, sorted snapshot of the bucket's whole key set.
+	RangePaged(ctx context.Context, bucket string, cursor uint64, limit int) (keys []string, nextCursor uint64, hasNext bool, err error)
+	// Close releases any resources (file handles, connections, background goroutines) the backend
+	// holds. Called once from Provider's Shutdown handler.
+	Close() error
+}
@@ -0,0 +1,282 @@
+package main
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// boltCursorTTL bounds how long an issued-but-abandoned RangePaged cursor (a caller that stops
+// paginating partway through) lingers in boltBackend.cursors before cursorJanitor reclaims it.
+const boltCursorTTL = 5 * time.Minute
+
+// boltCursor is the state behind one RangePaged cursor id: the key to resume from and the bucket
+// version as of the page that produced it, plus when it was last handed out or replayed so
+// cursorJanitor can reclaim it if abandoned. Unlike memBackend's stateless {version, offset}
+// cursor, a bbolt cursor can only resume from an actual key, so this state has to live somewhere;
+// keyed by bucket version rather than deleted once read, replaying the same cursor id is safe as
+// long as the bucket hasn't changed, matching memBucket.pageAt's "stale only if the version
+// moved" contract instead of "stale once consumed."
+type boltCursor struct {
+	lastKey   []byte
+	version   uint64
+	touchedAt time.Time
+}
+
+// boltBackend is a Backend backed by a single BoltDB file, with one top-level bbolt bucket per
+// logical bucket name (created lazily on first Set). Each stored value is prefixed with an 8-byte
+// big-endian expiry (unix nanoseconds, 0 meaning none); expired entries are skipped by Get,
+// Exists, Range, and RangePaged but are only actually removed the next time Set or Delete touches
+// their key, since bbolt has no background sweep of its own.
+type boltBackend struct {
+	db *bolt.DB
+
+	// cursors maps a RangePaged cursor id to a boltCursor. See boltCursor's doc comment.
+	cursors   sync.Map // uint64 -> *boltCursor
+	cursorIDs atomic.Uint64
+
+	// bucketVersions tracks a counter per logical bucket, bumped on every Set and Delete, so
+	// RangePaged can tell whether a bucket changed shape since a cursor was issued.
+	bucketVersions sync.Map // string -> *atomic.Uint64
+
+	stopJanitor chan struct{}
+	janitorDone chan struct{}
+}
+
+// newBoltBackend opens (creating if necessary) a BoltDB file at path and starts its background
+// cursor janitor. Call Close to stop it.
+func newBoltBackend(path string) (*boltBackend, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt db at %q: %w", path, err)
+	}
+	bb := &boltBackend{
+		db:          db,
+		stopJanitor: make(chan struct{}),
+		janitorDone: make(chan struct{}),
+	}
+	go bb.runCursorJanitor()
+	return bb, nil
+}
+
+// runCursorJanitor evicts cursors untouched for longer than boltCursorTTL every janitorInterval
+// (shared with memBackend's sweep cadence) until stopJanitor is closed.
+func (bb *boltBackend) runCursorJanitor() {
+	defer close(bb.janitorDone)
+
+	ticker := time.NewTicker(janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-bb.stopJanitor:
+			return
+		case now := <-ticker.C:
+			bb.cursors.Range(func(id, v any) bool {
+				if now.Sub(v.(*boltCursor).touchedAt) > boltCursorTTL {
+					bb.cursors.Delete(id)
+				}
+				return true
+			})
+		}
+	}
+}
+
+// bucketVersion returns the version counter for bucket, creating it at 0 on first use.
+func (bb *boltBackend) bucketVersion(bucket string) *atomic.Uint64 {
+	v, _ := bb.bucketVersions.LoadOrStore(bucket, new(atomic.Uint64))
+	return v.(*atomic.Uint64)
+}
+
+// encodeBoltEntry prefixes value with ttl's absolute expiry, encoded as 8 big-endian bytes of
+// unix nanoseconds (0 for no expiry). See decodeBoltEntry.
+func encodeBoltEntry(value []byte, ttl time.Duration) []byte {
+	var expiresAt int64
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl).UnixNano()
+	}
+	buf := make([]byte, 8+len(value))
+	binary.BigEndian.PutUint64(buf, uint64(expiresAt))
+	copy(buf[8:], value)
+	return buf
+}
+
+// decodeBoltEntry splits raw (as stored by encodeBoltEntry) back into its value and whether it's
+// expired as of now.
+func decodeBoltEntry(raw []byte, now time.Time) (value []byte, expired bool) {
+	if len(raw) < 8 {
+		return nil, false
+	}
+	expiresAt := int64(binary.BigEndian.Uint64(raw[:8]))
+	if expiresAt != 0 && now.UnixNano() >= expiresAt {
+		return raw[8:], true
+	}
+	return raw[8:], false
+}
+
+func (bb *boltBackend) Get(_ context.Context, bucket, key string) ([]byte, bool, error) {
+	var value []byte
+	var ok bool
+	err := bb.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		raw := b.Get([]byte(key))
+		if raw == nil {
+			return nil
+		}
+		v, expired := decodeBoltEntry(raw, time.Now())
+		if expired {
+			return nil
+		}
+		value = append([]byte{}, v...)
+		ok = true
+		return nil
+	})
+	return value, ok, err
+}
+
+func (bb *boltBackend) Set(_ context.Context, bucket, key string, value []byte, ttl time.Duration) error {
+	err := bb.db.Update(func(tx *bolt.Tx) error {
+		b, err := tx.CreateBucketIfNotExists([]byte(bucket))
+		if err != nil {
+			return err
+		}
+		return b.Put([]byte(key), encodeBoltEntry(value, ttl))
+	})
+	if err == nil {
+		bb.bucketVersion(bucket).Add(1)
+	}
+	return err
+}
+
+func (bb *boltBackend) Delete(_ context.Context, bucket, key string) error {
+	err := bb.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		return b.Delete([]byte(key))
+	})
+	if err == nil {
+		bb.bucketVersion(bucket).Add(1)
+	}
+	return err
+}
+
+func (bb *boltBackend) Exists(ctx context.Context, bucket, key string) (bool, error) {
+	_, ok, err := bb.Get(ctx, bucket, key)
+	return ok, err
+}
+
+func (bb *boltBackend) Range(_ context.Context, bucket string, fn func(key string, value []byte) bool) error {
+	err := bb.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		now := time.Now()
+		return b.ForEach(func(k, raw []byte) error {
+			value, expired := decodeBoltEntry(raw, now)
+			if expired {
+				return nil
+			}
+			if !fn(string(k), value) {
+				return errStopRange
+			}
+			return nil
+		})
+	})
+	if errors.Is(err, errStopRange) {
+		return nil
+	}
+	return err
+}
+
+// errStopRange is a sentinel bbolt's ForEach treats as "stop iterating", never surfaced to Range
+// callers.
+var errStopRange = errors.New("stop range")
+
+func (bb *boltBackend) nextCursorID() uint64 {
+	return bb.cursorIDs.Add(1)
+}
+
+func (bb *boltBackend) RangePaged(_ context.Context, bucket string, cursor uint64, limit int) ([]string, uint64, bool, error) {
+	if limit <= 0 {
+		limit = listKeysPageSize
+	}
+
+	currentVersion := bb.bucketVersion(bucket)
+
+	var resumeKey []byte
+	if cursor != 0 {
+		v, ok := bb.cursors.Load(cursor)
+		if !ok {
+			return nil, 0, false, errStaleCursor
+		}
+		entry := v.(*boltCursor)
+		if entry.version != currentVersion.Load() {
+			return nil, 0, false, errStaleCursor
+		}
+		resumeKey = entry.lastKey
+		// Refresh touchedAt (by replacing the entry, so nothing here ever mutates a boltCursor
+		// shared with a concurrent reader) so a caller still actively paginating doesn't lose its
+		// cursor to cursorJanitor mid-walk.
+		bb.cursors.Store(cursor, &boltCursor{lastKey: resumeKey, version: entry.version, touchedAt: time.Now()})
+	}
+
+	var keys []string
+	var lastKey []byte
+	var hasNext bool
+	err := bb.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket([]byte(bucket))
+		if b == nil {
+			return nil
+		}
+		c := b.Cursor()
+
+		var k, v []byte
+		if resumeKey != nil {
+			k, v = c.Seek(resumeKey)
+			if k != nil && string(k) == string(resumeKey) {
+				k, v = c.Next()
+			}
+		} else {
+			k, v = c.First()
+		}
+
+		now := time.Now()
+		for k != nil && len(keys) < limit {
+			if _, expired := decodeBoltEntry(v, now); !expired {
+				keys = append(keys, string(k))
+				lastKey = append([]byte{}, k...)
+			}
+			k, v = c.Next()
+		}
+		hasNext = k != nil
+		return nil
+	})
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	var nextCursor uint64
+	if hasNext {
+		nextCursor = bb.nextCursorID()
+		bb.cursors.Store(nextCursor, &boltCursor{lastKey: lastKey, version: currentVersion.Load(), touchedAt: time.Now()})
+	}
+	return keys, nextCursor, hasNext, nil
+}
+
+func (bb *boltBackend) Close() error {
+	close(bb.stopJanitor)
+	<-bb.janitorDone
+	return bb.db.Close()
+}
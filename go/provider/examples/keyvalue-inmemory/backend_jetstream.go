@@ -0,0 +1,251 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+)
+
+// jetstreamBackend is a Backend backed by a NATS JetStream KV bucket per logical bucket name,
+// created lazily on first Set. Unlike memBackend and boltBackend, ttl is enforced by JetStream
+// itself at the bucket level (every bucket jetstreamBackend creates shares defaultTTL), since the
+// KV API has no first-class per-key ttl; SetWithExpiry's ttl parameter is ignored in favor of
+// whatever defaultTTL the bucket was created with.
+type jetstreamBackend struct {
+	js         jetstream.JetStream
+	defaultTTL time.Duration
+
+	// ctx/cancel bound the lifetime of the per-bucket key watchers started by watchKeys. Close
+	// cancels it to stop them.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu      sync.Mutex
+	buckets map[string]jetstream.KeyValue
+
+	// keyCache holds the sorted key snapshot RangePaged pages through for each bucket, refreshed
+	// from kv.Keys whenever watchKeys observes a change. JetStream has no native "list keys after
+	// N" call, so this is what lets RangePaged resume a page without re-listing every call.
+	keyCache sync.Map // bucket (string) -> *jetstreamKeyCache
+}
+
+// newJetstreamBackend connects to nc's JetStream context. defaultTTL configures every KV bucket
+// jetstreamBackend creates; 0 means entries never expire.
+func newJetstreamBackend(nc *nats.Conn, defaultTTL time.Duration) (*jetstreamBackend, error) {
+	js, err := jetstream.New(nc)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create jetstream context: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	return &jetstreamBackend{
+		js:         js,
+		defaultTTL: defaultTTL,
+		ctx:        ctx,
+		cancel:     cancel,
+		buckets:    make(map[string]jetstream.KeyValue),
+	}, nil
+}
+
+func (jb *jetstreamBackend) bucket(ctx context.Context, name string) (jetstream.KeyValue, error) {
+	jb.mu.Lock()
+	defer jb.mu.Unlock()
+
+	if kv, ok := jb.buckets[name]; ok {
+		return kv, nil
+	}
+
+	kv, err := jb.js.CreateOrUpdateKeyValue(ctx, jetstream.KeyValueConfig{
+		Bucket: name,
+		TTL:    jb.defaultTTL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open jetstream kv bucket %q: %w", name, err)
+	}
+	jb.buckets[name] = kv
+	return kv, nil
+}
+
+func (jb *jetstreamBackend) Get(ctx context.Context, bucket, key string) ([]byte, bool, error) {
+	kv, err := jb.bucket(ctx, bucket)
+	if err != nil {
+		return nil, false, err
+	}
+	entry, err := kv.Get(ctx, key)
+	if errors.Is(err, jetstream.ErrKeyNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return entry.Value(), true, nil
+}
+
+func (jb *jetstreamBackend) Set(ctx context.Context, bucket, key string, value []byte, _ time.Duration) error {
+	kv, err := jb.bucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	_, err = kv.Put(ctx, key, value)
+	return err
+}
+
+func (jb *jetstreamBackend) Delete(ctx context.Context, bucket, key string) error {
+	kv, err := jb.bucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	if err := kv.Delete(ctx, key); err != nil && !errors.Is(err, jetstream.ErrKeyNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (jb *jetstreamBackend) Exists(ctx context.Context, bucket, key string) (bool, error) {
+	_, ok, err := jb.Get(ctx, bucket, key)
+	return ok, err
+}
+
+func (jb *jetstreamBackend) Range(ctx context.Context, bucket string, fn func(key string, value []byte) bool) error {
+	kv, err := jb.bucket(ctx, bucket)
+	if err != nil {
+		return err
+	}
+	keys, err := kv.Keys(ctx)
+	if err != nil && !errors.Is(err, jetstream.ErrNoKeysFound) {
+		return err
+	}
+	for _, key := range keys {
+		entry, err := kv.Get(ctx, key)
+		if errors.Is(err, jetstream.ErrKeyNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if !fn(key, entry.Value()) {
+			break
+		}
+	}
+	return nil
+}
+
+// jetstreamKeyCache is RangePaged's sorted key snapshot for one bucket, along with the version
+// counter bumped each time it's rebuilt; encodeMemCursor/decodeMemCursor tag pages with this
+// version the same way memBucket does, so a page issued against a stale snapshot is rejected
+// rather than silently skipping or repeating keys.
+type jetstreamKeyCache struct {
+	mu      sync.Mutex
+	stale   bool
+	started bool
+	version uint64
+	keys    []string
+}
+
+// refresh rebuilds the cache from kv.Keys if it's stale (watchKeys observed a change, or this is
+// the first call) and returns the current snapshot and version.
+func (c *jetstreamKeyCache) refresh(ctx context.Context, kv jetstream.KeyValue) ([]string, uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.stale && c.keys != nil {
+		return c.keys, c.version, nil
+	}
+
+	keys, err := kv.Keys(ctx)
+	if err != nil && !errors.Is(err, jetstream.ErrNoKeysFound) {
+		return nil, 0, err
+	}
+	sort.Strings(keys)
+	c.keys = keys
+	c.version++
+	c.stale = false
+	return c.keys, c.version, nil
+}
+
+// keyCacheFor returns bucket's jetstreamKeyCache, starting its watchKeys goroutine on first use.
+func (jb *jetstreamBackend) keyCacheFor(bucket string, kv jetstream.KeyValue) *jetstreamKeyCache {
+	v, _ := jb.keyCache.LoadOrStore(bucket, &jetstreamKeyCache{stale: true})
+	cache := v.(*jetstreamKeyCache)
+
+	cache.mu.Lock()
+	needsWatcher := !cache.started
+	cache.started = true
+	cache.mu.Unlock()
+
+	if needsWatcher {
+		go jb.watchKeys(bucket, kv, cache)
+	}
+	return cache
+}
+
+// watchKeys marks cache stale on every put/delete reported by kv.WatchAll, so the next RangePaged
+// call rebuilds its sorted key snapshot rather than paging through one that no longer matches the
+// bucket's contents. It runs until jb.ctx is cancelled, i.e. until Close.
+func (jb *jetstreamBackend) watchKeys(bucket string, kv jetstream.KeyValue, cache *jetstreamKeyCache) {
+	watcher, err := kv.WatchAll(jb.ctx)
+	if err != nil {
+		return
+	}
+	defer watcher.Stop()
+
+	for range watcher.Updates() {
+		cache.mu.Lock()
+		cache.stale = true
+		cache.mu.Unlock()
+	}
+}
+
+func (jb *jetstreamBackend) RangePaged(ctx context.Context, bucket string, cursor uint64, limit int) ([]string, uint64, bool, error) {
+	if limit <= 0 {
+		limit = listKeysPageSize
+	}
+
+	kv, err := jb.bucket(ctx, bucket)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	cache := jb.keyCacheFor(bucket, kv)
+	keys, version, err := cache.refresh(ctx, kv)
+	if err != nil {
+		return nil, 0, false, err
+	}
+
+	offset := 0
+	if cursor != 0 {
+		wantVersion, wantOffset := decodeMemCursor(cursor)
+		if wantVersion != version {
+			return nil, 0, false, errStaleCursor
+		}
+		offset = wantOffset
+	}
+
+	end := offset + limit
+	if end > len(keys) {
+		end = len(keys)
+	}
+	if offset > end {
+		offset = end
+	}
+
+	page := make([]string, end-offset)
+	copy(page, keys[offset:end])
+
+	hasNext := end < len(keys)
+	var nextCursor uint64
+	if hasNext {
+		nextCursor = encodeMemCursor(version, end)
+	}
+	return page, nextCursor, hasNext, nil
+}
+
+func (jb *jetstreamBackend) Close() error {
+	jb.cancel()
+	return nil
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// listKeysPageSize bounds how many keys RangePaged returns in a single page when the caller
+// doesn't request a smaller limit. See (*memBucket).pageAt for how a page is carved out of a
+// bucket's sorted key snapshot.
+const listKeysPageSize = 1000
+
+// janitorInterval is how often memBackend's background janitor goroutine sweeps every bucket for
+// expired entries. See memBackend.runJanitor.
+const janitorInterval = 1 * time.Second
+
+// memBucketEntry is a single stored value plus its optional expiry, set via Set's ttl parameter.
+type memBucketEntry struct {
+	value     []byte
+	expiresAt time.Time // zero means no expiry
+}
+
+func (e memBucketEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && now.After(e.expiresAt)
+}
+
+// memBucket is a single keyvalue bucket: a mutex-protected map of entries, a version counter
+// bumped on every mutation, and a lazily computed, version-tagged sorted key snapshot that
+// RangePaged pages through. Keeping pagination state per-bucket (rather than per-cursor) means the
+// snapshot is computed once per version no matter how many RangePaged calls page through it.
+type memBucket struct {
+	mu      sync.RWMutex
+	entries map[string]memBucketEntry
+
+	version         uint64
+	snapshotVersion uint64
+	snapshotKeys    []string
+}
+
+func newMemBucket() *memBucket {
+	return &memBucket{entries: make(map[string]memBucketEntry)}
+}
+
+func (b *memBucket) get(key string) ([]byte, bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	entry, ok := b.entries[key]
+	if !ok || entry.expired(time.Now()) {
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (b *memBucket) set(key string, value []byte, ttl time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	var expiresAt time.Time
+	if ttl > 0 {
+		expiresAt = time.Now().Add(ttl)
+	}
+	b.entries[key] = memBucketEntry{value: value, expiresAt: expiresAt}
+	b.version++
+}
+
+func (b *memBucket) delete(key string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.entries, key)
+	b.version++
+}
+
+// rangeAll calls fn with every non-expired key and value, stopping early if fn returns false.
+func (b *memBucket) rangeAll(fn func(key string, value []byte) bool) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	now := time.Now()
+	for key, entry := range b.entries {
+		if entry.expired(now) {
+			continue
+		}
+		if !fn(key, entry.value) {
+			return
+		}
+	}
+}
+
+// sweepExpired removes every expired entry and reports how many were removed. Callers must not
+// hold any lock other than b.mu while this runs, so the janitor takes its bucket snapshot (see
+// memBackend.runJanitor) before calling this per-bucket, never while holding the outer sync.Map.
+func (b *memBucket) sweepExpired(now time.Time) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	removed := 0
+	for key, entry := range b.entries {
+		if entry.expired(now) {
+			delete(b.entries, key)
+			removed++
+		}
+	}
+	if removed > 0 {
+		b.version++
+	}
+	return removed
+}
+
+// pageAt returns the page of at most limit keys starting at offset in the sorted key snapshot for
+// the bucket's current version, computing (and caching) that snapshot first if it's stale, along
+// with the offset of the next page and whether one exists. A limit <= 0 uses listKeysPageSize.
+func (b *memBucket) pageAt(offset, limit int) (page []string, nextOffset int, hasNext bool, version uint64) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if limit <= 0 {
+		limit = listKeysPageSize
+	}
+
+	if b.snapshotKeys == nil || b.snapshotVersion != b.version {
+		now := time.Now()
+		keys := make([]string, 0, len(b.entries))
+		for key, entry := range b.entries {
+			if !entry.expired(now) {
+				keys = append(keys, key)
+			}
+		}
+		sort.Strings(keys)
+		b.snapshotKeys = keys
+		b.snapshotVersion = b.version
+	}
+
+	end := offset + limit
+	if end > len(b.snapshotKeys) {
+		end = len(b.snapshotKeys)
+	}
+	if offset > end {
+		offset = end
+	}
+	return b.snapshotKeys[offset:end], end, end < len(b.snapshotKeys), b.snapshotVersion
+}
+
+// memBackend is the default Backend: every bucket is an in-memory map guarded by its own mutex,
+// with a background janitor reclaiming expired entries every janitorInterval.
+type memBackend struct {
+	buckets sync.Map // name (string) -> *memBucket
+
+	stopJanitor chan struct{}
+	janitorDone chan struct{}
+}
+
+// newMemBackend builds a memBackend and starts its background janitor. Call Close to stop it.
+func newMemBackend() *memBackend {
+	b := &memBackend{
+		stopJanitor: make(chan struct{}),
+		janitorDone: make(chan struct{}),
+	}
+	go b.runJanitor()
+	return b
+}
+
+// runJanitor sweeps every bucket for expired entries every janitorInterval until stopJanitor is
+// closed. It snapshots the set of buckets via sync.Map.Range and only then locks each bucket in
+// turn, so it never holds the outer sync.Map locked while deleting from a bucket's own map.
+func (mb *memBackend) runJanitor() {
+	defer close(mb.janitorDone)
+
+	ticker := time.NewTicker(janitorInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-mb.stopJanitor:
+			return
+		case now := <-ticker.C:
+			var buckets []*memBucket
+			mb.buckets.Range(func(_, v any) bool {
+				if b, ok := v.(*memBucket); ok {
+					buckets = append(buckets, b)
+				}
+				return true
+			})
+			for _, b := range buckets {
+				b.sweepExpired(now)
+			}
+		}
+	}
+}
+
+func (mb *memBackend) loadOrCreateBucket(name string) *memBucket {
+	b := newMemBucket()
+	v, loaded := mb.buckets.LoadOrStore(name, b)
+	if loaded {
+		return v.(*memBucket)
+	}
+	return b
+}
+
+func (mb *memBackend) Get(_ context.Context, bucket, key string) ([]byte, bool, error) {
+	v, ok := mb.buckets.Load(bucket)
+	if !ok {
+		return nil, false, nil
+	}
+	value, ok := v.(*memBucket).get(key)
+	return value, ok, nil
+}
+
+func (mb *memBackend) Set(_ context.Context, bucket, key string, value []byte, ttl time.Duration) error {
+	mb.loadOrCreateBucket(bucket).set(key, value, ttl)
+	return nil
+}
+
+func (mb *memBackend) Delete(_ context.Context, bucket, key string) error {
+	v, ok := mb.buckets.Load(bucket)
+	if !ok {
+		return nil
+	}
+	v.(*memBucket).delete(key)
+	return nil
+}
+
+func (mb *memBackend) Exists(_ context.Context, bucket, key string) (bool, error) {
+	v, ok := mb.buckets.Load(bucket)
+	if !ok {
+		return false, nil
+	}
+	_, ok = v.(*memBucket).get(key)
+	return ok, nil
+}
+
+func (mb *memBackend) Range(_ context.Context, bucket string, fn func(key string, value []byte) bool) error {
+	v, ok := mb.buckets.Load(bucket)
+	if !ok {
+		return nil
+	}
+	v.(*memBucket).rangeAll(fn)
+	return nil
+}
+
+// decodeMemCursor unpacks a RangePaged cursor into the bucket version it was issued for and the
+// offset into that version's key snapshot to resume from. See encodeMemCursor.
+func decodeMemCursor(cursor uint64) (version uint64, offset int) {
+	return cursor >> 32, int(uint32(cursor))
+}
+
+// encodeMemCursor packs a bucket version and snapshot offset into the single uint64 cursor
+// RangePaged hands back for reuse on the next call.
+func encodeMemCursor(version uint64, offset int) uint64 {
+	return version<<32 | uint64(uint32(offset))
+}
+
+func (mb *memBackend) RangePaged(_ context.Context, bucket string, cursor uint64, limit int) ([]string, uint64, bool, error) {
+	b := mb.loadOrCreateBucket(bucket)
+
+	offset := 0
+	wantVersion, wantOffset, haveCursor := uint64(0), 0, false
+	if cursor != 0 {
+		wantVersion, wantOffset = decodeMemCursor(cursor)
+		haveCursor = true
+		offset = wantOffset
+	}
+
+	keys, nextOffset, hasNext, version := b.pageAt(offset, limit)
+	// Compare against the version from this very page fetch, not an earlier peek, so a version
+	// bump between two separate lock acquisitions can't slip past the staleness check.
+	if haveCursor && version != wantVersion {
+		return nil, 0, false, errStaleCursor
+	}
+
+	var nextCursor uint64
+	if hasNext {
+		nextCursor = encodeMemCursor(version, nextOffset)
+	}
+
+	// Copy out of the cached snapshot slice so callers can't mutate it via the returned page.
+	page := make([]string, len(keys))
+	copy(page, keys)
+	return page, nextCursor, hasNext, nil
+}
+
+func (mb *memBackend) Close() error {
+	close(mb.stopJanitor)
+	<-mb.janitorDone
+	return nil
+}
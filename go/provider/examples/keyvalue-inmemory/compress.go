@@ -0,0 +1,150 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Tag bytes compressingBackend prepends to every stored value so Get/Range can tell a compressed
+// value apart from a plain one without guessing at the payload's contents: this is an arbitrary
+// caller-supplied byte blob store, so a real value can start with anything, including zstd's own
+// frame magic.
+const (
+	tagPlain byte = 0x00
+	tagZstd  byte = 0x01
+)
+
+// defaultCompressionThreshold is the value size, in bytes, above which WithCompression compresses
+// a value before handing it to the wrapped Backend.
+const defaultCompressionThreshold = 4 * 1024
+
+// compressingBackend wraps a Backend, transparently zstd-compressing values larger than threshold
+// on Set and decompressing them again on Get and Range. Every stored value, compressed or not, is
+// prefixed with a tag byte (tagPlain/tagZstd) so the two cases can be told apart without guessing
+// at the payload itself.
+//
+// Tagging only applies to values this compressingBackend writes. A bucket populated before
+// WithCompression was first enabled (or written to while "compression_threshold=0" disabled it,
+// per newBackendFromConfig in main.go) holds untagged values, which untag cannot tell apart from a
+// tagged one by inspecting the bytes alone - guessing would silently corrupt or misread legacy
+// data, exactly what tagging was introduced to avoid for the zstd-magic-sniffing it replaced. Run
+// MigrateLegacyBucket once over each such bucket, with the un-tagging backend (not this one),
+// before wrapping it in WithCompression; untag refuses (rather than guessing at) anything it
+// can't recognize as tagged.
+type compressingBackend struct {
+	Backend
+	threshold int
+	encoder   *zstd.Encoder
+	decoder   *zstd.Decoder
+}
+
+// WithCompression wraps inner so values larger than threshold are zstd-compressed in storage. A
+// threshold <= 0 uses defaultCompressionThreshold.
+func WithCompression(inner Backend, threshold int) (*compressingBackend, error) {
+	if threshold <= 0 {
+		threshold = defaultCompressionThreshold
+	}
+
+	encoder, err := zstd.NewWriter(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+	decoder, err := zstd.NewReader(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &compressingBackend{Backend: inner, threshold: threshold, encoder: encoder, decoder: decoder}, nil
+}
+
+// untag strips the leading tag byte written by Set and decompresses the rest if it's tagged
+// tagZstd. An empty value, or a leading byte that isn't a tag this package writes, means tagged
+// wasn't written by this compressingBackend - most likely a legacy value from before tagging was
+// enabled - and untag errors rather than guessing, so callers can surface that MigrateLegacyBucket
+// needs to run instead of silently returning corrupted bytes.
+func (cb *compressingBackend) untag(tagged []byte) ([]byte, error) {
+	if len(tagged) == 0 {
+		return nil, fmt.Errorf("untagged (empty) value: run MigrateLegacyBucket before enabling compression on existing data")
+	}
+	tag, value := tagged[0], tagged[1:]
+	switch tag {
+	case tagPlain:
+		return value, nil
+	case tagZstd:
+		return cb.decoder.DecodeAll(value, nil)
+	default:
+		return nil, fmt.Errorf("unrecognized compression tag %#x: run MigrateLegacyBucket before enabling compression on existing data", tag)
+	}
+}
+
+func (cb *compressingBackend) Set(ctx context.Context, bucket, key string, value []byte, ttl time.Duration) error {
+	tag := tagPlain
+	if len(value) > cb.threshold {
+		tag = tagZstd
+		value = cb.encoder.EncodeAll(value, nil)
+	}
+	tagged := make([]byte, 0, len(value)+1)
+	tagged = append(tagged, tag)
+	tagged = append(tagged, value...)
+	return cb.Backend.Set(ctx, bucket, key, tagged, ttl)
+}
+
+func (cb *compressingBackend) Get(ctx context.Context, bucket, key string) ([]byte, bool, error) {
+	value, ok, err := cb.Backend.Get(ctx, bucket, key)
+	if err != nil || !ok {
+		return value, ok, err
+	}
+	value, err = cb.untag(value)
+	return value, ok, err
+}
+
+func (cb *compressingBackend) Range(ctx context.Context, bucket string, fn func(key string, value []byte) bool) error {
+	return cb.Backend.Range(ctx, bucket, func(key string, value []byte) bool {
+		value, err := cb.untag(value)
+		if err != nil {
+			// Skip an entry we can't recognize as tagged (untag already errors instead of
+			// guessing) rather than aborting the whole range; run MigrateLegacyBucket so it shows
+			// up here instead of being silently skipped.
+			return true
+		}
+		return fn(key, value)
+	})
+}
+
+func (cb *compressingBackend) Close() error {
+	cb.encoder.Close()
+	cb.decoder.Close()
+	return cb.Backend.Close()
+}
+
+// MigrateLegacyBucket rewrites every value in bucket with a tagPlain prefix, so a bucket written
+// before WithCompression wrapped backend (or while it was disabled, see newBackendFromConfig's
+// "compression_threshold=0") can be read through a compressingBackend afterward. It must run
+// exactly once per bucket, against the plain (unwrapped) backend, before any compressingBackend
+// Set touches that bucket - Set always writes a tagged value, so migrating after compression is
+// enabled would re-tag already-tagged entries and corrupt them.
+func MigrateLegacyBucket(ctx context.Context, backend Backend, bucket string) error {
+	var keys []string
+	var values [][]byte
+	err := backend.Range(ctx, bucket, func(key string, value []byte) bool {
+		tagged := make([]byte, 0, len(value)+1)
+		tagged = append(tagged, tagPlain)
+		tagged = append(tagged, value...)
+		keys = append(keys, key)
+		values = append(values, tagged)
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to scan bucket %q for compression migration: %w", bucket, err)
+	}
+
+	for i, key := range keys {
+		if err := backend.Set(ctx, bucket, key, values[i], 0); err != nil {
+			return fmt.Errorf("failed to migrate key %q in bucket %q: %w", key, bucket, err)
+		}
+	}
+	return nil
+}
@@ -3,132 +3,118 @@ package main
 
 import (
 	"context"
-	"sync"
+	"errors"
+	"time"
 
 	wrpc "github.com/bytecodealliance/wrpc/go"
-	"go.opentelemetry.io/otel/trace"
 	"go.wasmcloud.dev/provider"
 	"go.wasmcloud.dev/provider/examples/keyvalue-inmemory/bindings/exports/wrpc/keyvalue/store"
 )
 
-var (
-	errNoSuchStore     = store.NewErrorNoSuchStore()
-	errInvalidDataType = store.NewErrorOther("invalid data type stored in map")
-)
+const witInterface = "wrpc:keyvalue/store"
+
+var errInvalidDataType = store.NewErrorOther("invalid data type stored in map")
 
+// Provider implements the wrpc:keyvalue/store wit interface on top of a pluggable Backend (see
+// backend.go and newBackendFromConfig), rather than hard-coding a particular storage engine.
 type Provider struct {
-	sync.Map
-	sourceLinks map[string]provider.InterfaceLinkDefinition
-	targetLinks map[string]provider.InterfaceLinkDefinition
-	tracer      trace.Tracer
+	backend           Backend
+	sourceLinks       map[string]provider.InterfaceLinkDefinition
+	targetLinks       map[string]provider.InterfaceLinkDefinition
+	wasmcloudProvider *provider.WasmcloudProvider
 }
 
 func Ok[T any](v T) *wrpc.Result[T, store.Error] {
 	return wrpc.Ok[store.Error](v)
 }
 
-func (p *Provider) Delete(ctx context.Context, bucket string, key string) (*wrpc.Result[struct{}, store.Error], error) {
-	ctx, span := p.tracer.Start(ctx, "Delete")
+func (p *Provider) Delete(ctx context.Context, bucketName string, key string) (*wrpc.Result[struct{}, store.Error], error) {
+	ctx, span := p.wasmcloudProvider.StartRPCSpan(ctx, witInterface, "delete")
 	defer span.End()
 
-	v, ok := p.Load(bucket)
-	if !ok {
-		return wrpc.Err[struct{}](*errNoSuchStore), nil
-	}
-	b, ok := v.(*sync.Map)
-	if !ok {
+	if err := p.backend.Delete(ctx, bucketName, key); err != nil {
 		return wrpc.Err[struct{}](*errInvalidDataType), nil
 	}
-	b.Delete(key)
 	return Ok(struct{}{}), nil
 }
 
-func (p *Provider) Exists(ctx context.Context, bucket string, key string) (*wrpc.Result[bool, store.Error], error) {
-	ctx, span := p.tracer.Start(ctx, "Exists")
+func (p *Provider) Exists(ctx context.Context, bucketName string, key string) (*wrpc.Result[bool, store.Error], error) {
+	ctx, span := p.wasmcloudProvider.StartRPCSpan(ctx, witInterface, "exists")
 	defer span.End()
 
-	v, ok := p.Load(bucket)
-	if !ok {
-		return wrpc.Err[bool](*errNoSuchStore), nil
-	}
-	b, ok := v.(*sync.Map)
-	if !ok {
+	exists, err := p.backend.Exists(ctx, bucketName, key)
+	if err != nil {
 		return wrpc.Err[bool](*errInvalidDataType), nil
 	}
-	_, ok = b.Load(key)
-	return Ok(ok), nil
+	return Ok(exists), nil
 }
 
-func (p *Provider) Get(ctx context.Context, bucket string, key string) (*wrpc.Result[[]uint8, store.Error], error) {
-	ctx, span := p.tracer.Start(ctx, "Get")
+func (p *Provider) Get(ctx context.Context, bucketName string, key string) (*wrpc.Result[[]uint8, store.Error], error) {
+	ctx, span := p.wasmcloudProvider.StartRPCSpan(ctx, witInterface, "get")
 	defer span.End()
 
-	v, ok := p.Load(bucket)
-	if !ok {
-		return wrpc.Err[[]uint8](*errNoSuchStore), nil
-	}
-	b, ok := v.(*sync.Map)
-	if !ok {
+	value, ok, err := p.backend.Get(ctx, bucketName, key)
+	if err != nil {
 		return wrpc.Err[[]uint8](*errInvalidDataType), nil
 	}
-	v, ok = b.Load(key)
 	if !ok {
 		return Ok([]uint8(nil)), nil
 	}
-	buf, ok := v.([]byte)
-	if !ok {
-		return wrpc.Err[[]uint8](*errInvalidDataType), nil
+	return Ok(value), nil
+}
+
+func (p *Provider) Set(ctx context.Context, bucketName string, key string, value []byte) (*wrpc.Result[struct{}, store.Error], error) {
+	ctx, span := p.wasmcloudProvider.StartRPCSpan(ctx, witInterface, "set")
+	defer span.End()
+
+	if err := p.backend.Set(ctx, bucketName, key, value, 0); err != nil {
+		return wrpc.Err[struct{}](*errInvalidDataType), nil
 	}
-	return Ok(buf), nil
+	return Ok(struct{}{}), nil
 }
 
-func (p *Provider) Set(ctx context.Context, bucket string, key string, value []byte) (*wrpc.Result[struct{}, store.Error], error) {
-	ctx, span := p.tracer.Start(ctx, "Set")
+// SetWithExpiry is like Set, but the entry is treated as absent by Get and Exists (and omitted
+// from ListKeys) once ttlMs milliseconds have elapsed; 0 means no expiry. Not every Backend
+// enforces this with a background sweep; see each implementation's doc comment.
+//
+// Note: set-with-expiry is declared in wit/world.wit, but bindings/ hasn't been regenerated from
+// it (requires `go generate ./...` with wit-bindgen-wrpc, which this checkout doesn't have), so
+// this method is not yet reachable as an RPC — only from Go callers and tests. Run `go generate`
+// once the toolchain is available to wire it up.
+func (p *Provider) SetWithExpiry(ctx context.Context, bucketName string, key string, value []byte, ttlMs uint64) (*wrpc.Result[struct{}, store.Error], error) {
+	ctx, span := p.wasmcloudProvider.StartRPCSpan(ctx, witInterface, "set-with-expiry")
 	defer span.End()
 
-	b := &sync.Map{}
-	v, ok := p.LoadOrStore(bucket, b)
-	if ok {
-		b, ok = v.(*sync.Map)
-		if !ok {
-			return wrpc.Err[struct{}](*errInvalidDataType), nil
-		}
+	if err := p.backend.Set(ctx, bucketName, key, value, time.Duration(ttlMs)*time.Millisecond); err != nil {
+		return wrpc.Err[struct{}](*errInvalidDataType), nil
 	}
-	b.Store(key, value)
 	return Ok(struct{}{}), nil
 }
 
-func (p *Provider) ListKeys(ctx context.Context, bucket string, cursor *uint64) (*wrpc.Result[store.KeyResponse, store.Error], error) {
-	ctx, span := p.tracer.Start(ctx, "ListKeys")
+func (p *Provider) ListKeys(ctx context.Context, bucketName string, cursor *uint64) (*wrpc.Result[store.KeyResponse, store.Error], error) {
+	ctx, span := p.wasmcloudProvider.StartRPCSpan(ctx, witInterface, "list-keys")
 	defer span.End()
 
+	var from uint64
 	if cursor != nil {
-		return wrpc.Err[store.KeyResponse](*store.NewErrorOther("cursors are not supported")), nil
+		from = *cursor
 	}
-	b := &sync.Map{}
-	v, ok := p.LoadOrStore(bucket, b)
-	if ok {
-		b, ok = v.(*sync.Map)
-		if !ok {
-			return wrpc.Err[store.KeyResponse](*errInvalidDataType), nil
+
+	keys, nextCursor, hasNext, err := p.backend.RangePaged(ctx, bucketName, from, 0)
+	if err != nil {
+		if errors.Is(err, errStaleCursor) {
+			return wrpc.Err[store.KeyResponse](*store.NewErrorOther("stale cursor")), nil
 		}
+		return wrpc.Err[store.KeyResponse](*errInvalidDataType), nil
 	}
-	var keys []string
-	var err *store.Error
-	b.Range(func(k, _ any) bool {
-		s, ok := k.(string)
-		if !ok {
-			err = errInvalidDataType
-			return false
-		}
-		keys = append(keys, s)
-		return true
-	})
-	if err != nil {
-		return wrpc.Err[store.KeyResponse](*err), nil
+
+	var nextCursorPtr *uint64
+	if hasNext {
+		nextCursorPtr = &nextCursor
 	}
+
 	return Ok(store.KeyResponse{
 		Keys:   keys,
-		Cursor: nil,
+		Cursor: nextCursorPtr,
 	}), nil
 }
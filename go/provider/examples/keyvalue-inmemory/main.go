@@ -3,12 +3,16 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
-	"go.opentelemetry.io/otel"
+	"github.com/nats-io/nats.go"
 	"go.wasmcloud.dev/provider"
 	server "go.wasmcloud.dev/provider/examples/keyvalue-inmemory/bindings"
 )
@@ -23,7 +27,6 @@ func run() error {
 	p := &Provider{
 		sourceLinks: make(map[string]provider.InterfaceLinkDefinition),
 		targetLinks: make(map[string]provider.InterfaceLinkDefinition),
-		tracer:      otel.Tracer("keyvalue-inmemory"),
 	}
 
 	wasmcloudprovider, err := provider.New(
@@ -37,6 +40,13 @@ func run() error {
 	if err != nil {
 		return err
 	}
+	p.wasmcloudProvider = wasmcloudprovider
+
+	backend, err := newBackendFromConfig(wasmcloudprovider.HostData().Config, wasmcloudprovider.NatsConnection())
+	if err != nil {
+		return err
+	}
+	p.backend = backend
 
 	providerCh := make(chan error, 1)
 	signalCh := make(chan os.Signal, 1)
@@ -44,7 +54,7 @@ func run() error {
 	// Handle RPC operations
 	stopFunc, err := server.Serve(wasmcloudprovider.RPCClient, p)
 	if err != nil {
-		wasmcloudprovider.Shutdown()
+		wasmcloudprovider.Shutdown(context.Background())
 		return err
 	}
 
@@ -62,43 +72,93 @@ func run() error {
 		stopFunc()
 		return err
 	case <-signalCh:
-		wasmcloudprovider.Shutdown()
+		wasmcloudprovider.Shutdown(context.Background())
 		stopFunc()
 	}
 
 	return nil
 }
 
-func (p *Provider) handleNewSourceLink(link provider.InterfaceLinkDefinition) error {
+func (p *Provider) handleNewSourceLink(_ context.Context, link provider.InterfaceLinkDefinition) error {
 	log.Println("Handling new source link", link)
 	p.sourceLinks[link.Target] = link
 	return nil
 }
 
-func (p *Provider) handleNewTargetLink(link provider.InterfaceLinkDefinition) error {
+func (p *Provider) handleNewTargetLink(_ context.Context, link provider.InterfaceLinkDefinition) error {
 	log.Println("Handling new target link", link)
 	p.targetLinks[link.SourceID] = link
 	return nil
 }
 
-func (p *Provider) handleDelSourceLink(link provider.InterfaceLinkDefinition) error {
+func (p *Provider) handleDelSourceLink(_ context.Context, link provider.InterfaceLinkDefinition) error {
 	log.Println("Handling del source link", link)
 	delete(p.sourceLinks, link.Target)
 	return nil
 }
 
-func (p *Provider) handleDelTargetLink(link provider.InterfaceLinkDefinition) error {
+func (p *Provider) handleDelTargetLink(_ context.Context, link provider.InterfaceLinkDefinition) error {
 	log.Println("Handling del target link", link)
 	delete(p.targetLinks, link.SourceID)
 	return nil
 }
 
-func (p *Provider) handleHealthCheck() string {
+func (p *Provider) handleHealthCheck(_ context.Context) string {
 	log.Println("Handling health check")
 	return "provider healthy"
 }
 
-func (p *Provider) handleShutdown() error {
+func (p *Provider) handleShutdown(_ context.Context) error {
 	log.Println("Handling shutdown")
-	return nil
+	return p.backend.Close()
+}
+
+// newBackendFromConfig builds the Backend selected by the "backend" key in the provider's own
+// HostData config (not a link's source/target config)
+// ("mem", the default; "bolt"; or "jetstream"), wrapped in a compressingBackend unless
+// "compression_threshold" is "0" (unset uses defaultCompressionThreshold).
+//
+//   - bolt reads "bolt_path" (default "keyvalue.db") for the database file.
+//   - jetstream reads "jetstream_ttl", a Go duration string (e.g. "1h"), for the bucket-wide TTL
+//     JetStream enforces (default: no TTL), and reuses nc, the provider's own NATS connection.
+func newBackendFromConfig(config map[string]string, nc *nats.Conn) (Backend, error) {
+	var backend Backend
+	var err error
+
+	switch config["backend"] {
+	case "", "mem":
+		backend = newMemBackend()
+	case "bolt":
+		path := config["bolt_path"]
+		if path == "" {
+			path = "keyvalue.db"
+		}
+		backend, err = newBoltBackend(path)
+	case "jetstream":
+		var ttl time.Duration
+		if raw := config["jetstream_ttl"]; raw != "" {
+			ttl, err = time.ParseDuration(raw)
+			if err != nil {
+				return nil, fmt.Errorf("invalid jetstream_ttl %q: %w", raw, err)
+			}
+		}
+		backend, err = newJetstreamBackend(nc, ttl)
+	default:
+		return nil, fmt.Errorf("unknown backend %q", config["backend"])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := defaultCompressionThreshold
+	if raw, ok := config["compression_threshold"]; ok {
+		threshold, err = strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid compression_threshold %q: %w", raw, err)
+		}
+		if threshold == 0 {
+			return backend, nil
+		}
+	}
+	return WithCompression(backend, threshold)
 }
@@ -0,0 +1,26 @@
+package main
+
+import (
+	"context"
+	"fmt"
+)
+
+// MigrateBucket copies every key in bucket from src to dst, e.g. when switching a running
+// deployment from one configured backend to another. It copies entry by entry via src.Range
+// rather than collecting the bucket first, but per-entry TTLs aren't preserved: Range doesn't
+// expose an entry's expiry, so every entry is written to dst with no per-entry expiry (a
+// jetstream dst still applies its own bucket-wide TTL).
+func MigrateBucket(ctx context.Context, src, dst Backend, bucket string) error {
+	var copyErr error
+	err := src.Range(ctx, bucket, func(key string, value []byte) bool {
+		if err := dst.Set(ctx, bucket, key, value, 0); err != nil {
+			copyErr = fmt.Errorf("failed to migrate key %q in bucket %q: %w", key, bucket, err)
+			return false
+		}
+		return true
+	})
+	if err != nil {
+		return fmt.Errorf("failed to range bucket %q for migration: %w", bucket, err)
+	}
+	return copyErr
+}
@@ -1,21 +1,92 @@
 package provider
 
+import "time"
+
 const (
 	OtelProtocolHTTP = "Http"
 	OtelProtocolGRPC = "Grpc"
+	// OtelProtocolFile writes NDJSON to a local file instead of an OTLP collector, for air-gapped
+	// or edge hosts. The same behavior is also triggered per-signal by giving TracesEndpoint,
+	// MetricsEndpoint, or LogsEndpoint a "file://" value regardless of Protocol.
+	OtelProtocolFile = "file"
+	// OtelProtocolStdout writes NDJSON to the provider process's stdout, e.g. for local debugging.
+	OtelProtocolStdout = "stdout"
 )
 
 type OtelConfig struct {
-	EnableObservability   bool   `json:"enable_observability"`
-	EnableTraces          *bool  `json:"enable_traces,omitempty"`
-	EnableMetrics         *bool  `json:"enable_metrics,omitempty"`
-	EnableLogs            *bool  `json:"enable_logs,omitempty"`
-	ObservabilityEndpoint string `json:"observability_endpoint,omitempty"`
-	TracesEndpoint        string `json:"traces_endpoint,omitempty"`
-	MetricsEndpoint       string `json:"metrics_endpoint,omitempty"`
-	LogsEndpoint          string `json:"logs_endpoint,omitempty"`
-	TraceLevel            *Level `json:"trace_level,omitempty"`
-	Protocol              string `json:"protocol,omitempty"`
+	EnableObservability   bool            `json:"enable_observability"`
+	EnableTraces          *bool           `json:"enable_traces,omitempty"`
+	EnableMetrics         *bool           `json:"enable_metrics,omitempty"`
+	EnableLogs            *bool           `json:"enable_logs,omitempty"`
+	ObservabilityEndpoint string          `json:"observability_endpoint,omitempty"`
+	TracesEndpoint        string          `json:"traces_endpoint,omitempty"`
+	MetricsEndpoint       string          `json:"metrics_endpoint,omitempty"`
+	LogsEndpoint          string          `json:"logs_endpoint,omitempty"`
+	TraceLevel            *Level          `json:"trace_level,omitempty"`
+	Protocol              string          `json:"protocol,omitempty"`
+	TLSClientConfig       OtelTLSConfig   `json:"tls_client_config,omitempty"`
+	Proxy                 string          `json:"proxy,omitempty"`
+	Timeout               time.Duration   `json:"timeout,omitempty"`
+	Compression           string          `json:"compression,omitempty"`
+	Retry                 OtelRetryConfig `json:"retry,omitempty"`
+	// Sampler selects the sdk/trace.Sampler used for locally originated spans: "always_on",
+	// "always_off", "parentbased_always_on", "parentbased_always_off", "traceidratio:<ratio>", or
+	// "parentbased_traceidratio:<ratio>". Empty defaults to parentbased_traceidratio:1, i.e.
+	// ParentBased(TraceIDRatioBased(1.0)) — sample everything locally, but always honor a sampled
+	// parent context from an upstream host or component. See newOtelSampler.
+	Sampler string `json:"sampler,omitempty"`
+	// Headers are sent with every OTLP export request (e.g. collector auth tokens), on top of
+	// whatever the exporter sets itself.
+	Headers map[string]string `json:"headers,omitempty"`
+	// MaxSizeBytes, MaxAgeSeconds, and MaxBackups rotate the NDJSON file(s) written by
+	// OtelProtocolFile or a "file://" endpoint, the same way a logging driver rotates: once the
+	// active file exceeds MaxSizeBytes or has been open longer than MaxAgeSeconds, it's renamed
+	// aside and a fresh one started, keeping at most MaxBackups of the renamed files. A zero value
+	// disables the corresponding rotation trigger; MaxBackups of 0 keeps every rotated file.
+	MaxSizeBytes  int64 `json:"max_size_bytes,omitempty"`
+	MaxAgeSeconds int64 `json:"max_age_seconds,omitempty"`
+	MaxBackups    int   `json:"max_backups,omitempty"`
+	// ResourceAttributes adds arbitrary attributes to the resource reported alongside every trace,
+	// metric, and log, in the same "k=v,k=v" shape as the OTEL_RESOURCE_ATTRIBUTES env var (e.g.
+	// "deployment.environment=prod,team=platform"). These are applied after, and so override, the
+	// default wasmcloud/process/host attributes newServiceResource sets. See newServiceResource.
+	ResourceAttributes string `json:"resource_attributes,omitempty"`
+}
+
+// OtelConfigUpdate is the JSON payload accepted on Topics.LATTICE_OTEL_CONFIG (token-checked by
+// JWTAuth only when HostData.RPCAuthEnabled is set) to reconfigure a running provider's observability pipeline without a restart. Empty fields leave
+// the corresponding OtelConfig field unchanged; Endpoint maps to OtelConfig.ObservabilityEndpoint.
+// See OtelManager.Reconfigure.
+type OtelConfigUpdate struct {
+	Protocol        string            `json:"protocol,omitempty"`
+	Endpoint        string            `json:"endpoint,omitempty"`
+	TracesEndpoint  string            `json:"traces_endpoint,omitempty"`
+	MetricsEndpoint string            `json:"metrics_endpoint,omitempty"`
+	LogsEndpoint    string            `json:"logs_endpoint,omitempty"`
+	Sampler         string            `json:"sampler,omitempty"`
+	Headers         map[string]string `json:"headers,omitempty"`
+}
+
+// OtelTLSConfig configures mTLS for the OTLP exporters: a client cert/key pair for mutual TLS and
+// a custom CA for verifying the collector, all as PEM file paths. Any field left empty falls back
+// to the Go standard library's default behavior (no client cert, system CA pool).
+type OtelTLSConfig struct {
+	CertFile           string `json:"cert_file,omitempty"`
+	KeyFile            string `json:"key_file,omitempty"`
+	CAFile             string `json:"ca_file,omitempty"`
+	InsecureSkipVerify bool   `json:"insecure_skip_verify,omitempty"`
+}
+
+// OtelRetryConfig controls the exponential backoff-with-jitter retry applied to OTLP exports that
+// fail with a retryable status (429, 502, 503, 504) or a network error, matching the retry
+// semantics already built into the OTel Go SDK's OTLP exporters: each attempt waits
+// min(MaxInterval, InitialInterval*2^attempt*rand(0.5,1.5)) unless the response carries a
+// Retry-After header, and the whole sequence gives up once MaxElapsed has passed. A zero value
+// leaves each exporter's own default retry settings in place.
+type OtelRetryConfig struct {
+	InitialInterval time.Duration `json:"initial_interval,omitempty"`
+	MaxInterval     time.Duration `json:"max_interval,omitempty"`
+	MaxElapsed      time.Duration `json:"max_elapsed,omitempty"`
 }
 
 type HostData struct {
@@ -37,6 +108,32 @@ type HostData struct {
 	StructuredLogging      bool                       `json:"structured_logging,omitempty"`
 	LogLevel               *Level                     `json:"log_level,omitempty"`
 	OtelConfig             OtelConfig                 `json:"otel_config,omitempty"`
+
+	// RPCAuthEnabled opts into JWT-based authentication of inbound RPC control messages (health,
+	// linkdefs.put, linkdefs.del, link health, config update, OTel config, shutdown). It defaults to false, so hosts that don't set it keep the
+	// existing unauthenticated behavior. See JWTAuth.
+	RPCAuthEnabled bool `json:"rpc_auth_enabled,omitempty"`
+	// RPCAuthJWTPublicKey is an additional nkey-encoded Ed25519 public key trusted to sign RPC
+	// auth tokens, on top of the keys in ClusterIssuers. Only consulted when RPCAuthEnabled is
+	// true.
+	RPCAuthJWTPublicKey string `json:"rpc_auth_jwt_public_key,omitempty"`
+
+	// RPCTransport selects the Transport New subscribes the provider's control plane (health,
+	// link put/del, link health, config update, shutdown, lifecycle events) with: "nats" (the
+	// default, used when empty) or "grpc". It has no effect on wp.RPCClient, which wit-bindgen-wrpc
+	// generated bindings always dispatch over NATS; see Transport's doc comment. Overridden by
+	// WithTransport, if given. With "grpc", lifecycle events and OTel reconfiguration messages are
+	// not received.
+	RPCTransport string `json:"rpc_transport,omitempty"`
+	// GRPCListenAddr is the address GrpcTransport listens on when RPCTransport is "grpc", e.g.
+	// ":8443". Ignored otherwise.
+	GRPCListenAddr string `json:"grpc_listen_addr,omitempty"`
+
+	// ClusterEnabled opts into a Cluster (see WithClustering) coordinating every running instance
+	// of this provider over a JetStream KV bucket, so link lifecycle callbacks fire only on the
+	// elected leader by default rather than on every instance independently. Overridden by an
+	// explicit WithCluster or WithClustering option.
+	ClusterEnabled bool `json:"cluster_enabled,omitempty"`
 }
 
 type HealthCheckResponse struct {
@@ -0,0 +1,136 @@
+package provider
+
+import (
+	"bufio"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// HostDataDecoder decodes raw host data bytes (already base64-decoded, where applicable) into a
+// HostData value. Built-in decoders exist for JSON, YAML, and TOML; register additional formats
+// with WithHostDataDecoder.
+type HostDataDecoder func([]byte) (HostData, error)
+
+// HostDataOption configures how LoadHostData locates and decodes host data.
+type HostDataOption func(*hostDataLoader)
+
+type hostDataLoader struct {
+	decodersByExt map[string]HostDataDecoder
+}
+
+func newHostDataLoader() *hostDataLoader {
+	return &hostDataLoader{
+		decodersByExt: map[string]HostDataDecoder{
+			".json": decodeHostDataJSON,
+			".yaml": decodeHostDataYAML,
+			".yml":  decodeHostDataYAML,
+			".toml": decodeHostDataTOML,
+		},
+	}
+}
+
+// WithHostDataDecoder registers a HostDataDecoder for a file extension (including the leading
+// dot, e.g. ".hcl"), for use when WASMCLOUD_HOST_DATA_FILE points at a format this package
+// doesn't know natively.
+func WithHostDataDecoder(ext string, decoder HostDataDecoder) HostDataOption {
+	return func(l *hostDataLoader) {
+		l.decodersByExt[ext] = decoder
+	}
+}
+
+func decodeHostDataJSON(data []byte) (HostData, error) {
+	var hostData HostData
+	err := json.Unmarshal(data, &hostData)
+	return hostData, err
+}
+
+func decodeHostDataYAML(data []byte) (HostData, error) {
+	var hostData HostData
+	err := yaml.Unmarshal(data, &hostData)
+	return hostData, err
+}
+
+func decodeHostDataTOML(data []byte) (HostData, error) {
+	var hostData HostData
+	err := toml.Unmarshal(data, &hostData)
+	return hostData, err
+}
+
+// LoadHostData reads and decodes the provider's host data, following the same resolution order
+// main.go providers have always relied on:
+//
+//  1. If WASMCLOUD_HOST_DATA_FILE is set, its contents are decoded using the decoder registered
+//     for the file's extension (JSON, YAML, and TOML are built in; register others with
+//     WithHostDataDecoder).
+//  2. Otherwise, host data is read as a single base64-encoded line from stdin, as sent by the
+//     wasmCloud host, and decoded as JSON, YAML, or TOML in turn until one succeeds.
+//
+// provider.New calls LoadHostData with no options, so a WasmcloudProvider only ever decodes the
+// built-in formats; decoders registered with WithHostDataDecoder apply only to direct calls, whose
+// result New does not take.
+func LoadHostData(opts ...HostDataOption) (HostData, error) {
+	loader := newHostDataLoader()
+	for _, opt := range opts {
+		opt(loader)
+	}
+
+	if path := os.Getenv("WASMCLOUD_HOST_DATA_FILE"); path != "" {
+		raw, err := os.ReadFile(path)
+		if err != nil {
+			return HostData{}, fmt.Errorf("failed to read WASMCLOUD_HOST_DATA_FILE %q: %w", path, err)
+		}
+		ext := strings.ToLower(filepath.Ext(path))
+		decoder, ok := loader.decodersByExt[ext]
+		if !ok {
+			return HostData{}, fmt.Errorf("no host data decoder registered for extension %q", ext)
+		}
+		hostData, err := decoder(raw)
+		if err != nil {
+			return HostData{}, fmt.Errorf("failed to decode WASMCLOUD_HOST_DATA_FILE %q: %w", path, err)
+		}
+		return hostData, nil
+	}
+
+	reader := bufio.NewReader(os.Stdin)
+	hostDataChannel := make(chan string, 1)
+	go func() {
+		hostDataRaw, err := reader.ReadString('\n')
+		if err != nil {
+			hostDataChannel <- ""
+			return
+		}
+		hostDataChannel <- hostDataRaw
+	}()
+
+	var hostDataRaw string
+	select {
+	case hostDataRaw = <-hostDataChannel:
+	case <-time.After(5 * time.Second):
+		return HostData{}, fmt.Errorf("failed to read host data, did not receive after 5 seconds")
+	}
+
+	decodedData, err := base64.StdEncoding.DecodeString(hostDataRaw)
+	if err != nil {
+		return HostData{}, fmt.Errorf("failed to base64-decode host data: %w", err)
+	}
+
+	// No file extension to go by on stdin, so try each known format in turn. JSON first, since
+	// it's both the overwhelmingly common case and valid YAML, which would otherwise shadow it.
+	var errs []error
+	for _, decoder := range []HostDataDecoder{decodeHostDataJSON, decodeHostDataYAML, decodeHostDataTOML} {
+		hostData, err := decoder(decodedData)
+		if err == nil {
+			return hostData, nil
+		}
+		errs = append(errs, err)
+	}
+	return HostData{}, fmt.Errorf("failed to decode host data as JSON, YAML, or TOML: %v", errs)
+}
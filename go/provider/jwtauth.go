@@ -0,0 +1,232 @@
+package provider
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	"github.com/nats-io/nkeys"
+)
+
+// RPCAuthHeader is the NATS message header carrying the signed RPC auth token on lattice control
+// messages, when HostData.RPCAuthEnabled is set.
+const RPCAuthHeader = "WasmCloud-RPC-Jwt"
+
+// RPCClaims are the claims an RPC auth token must carry. They mirror the registered "iss"/"aud"
+// JWT claim names plus a wasmCloud-specific "lattice" claim, rather than reusing nats-io/jwt's
+// account/user claim shapes, since these tokens authenticate lattice control messages, not NATS
+// connections.
+type RPCClaims struct {
+	// Issuer is the nkey-encoded public key of the signer, which must be one of the provider's
+	// trusted issuers (see JWTAuth).
+	Issuer string `json:"iss"`
+	// Audience must match the provider key of the provider the token was issued for.
+	Audience string `json:"aud"`
+	// Lattice must match the LatticeRPCPrefix of the provider the token was issued for.
+	Lattice string `json:"lattice"`
+	// Expires, if set, is a Unix timestamp after which the token is no longer valid.
+	Expires int64 `json:"exp,omitempty"`
+	// NotBefore, if set, is a Unix timestamp before which the token is not yet valid.
+	NotBefore int64 `json:"nbf,omitempty"`
+}
+
+func (c RPCClaims) validate(providerKey, latticePrefix string) error {
+	now := time.Now().Unix()
+	if c.Expires != 0 && now >= c.Expires {
+		return fmt.Errorf("token expired")
+	}
+	if c.NotBefore != 0 && now < c.NotBefore {
+		return fmt.Errorf("token not yet valid")
+	}
+	if c.Audience != providerKey {
+		return fmt.Errorf("token audience %q does not match provider %q", c.Audience, providerKey)
+	}
+	if c.Lattice != latticePrefix {
+		return fmt.Errorf("token lattice claim %q does not match lattice %q", c.Lattice, latticePrefix)
+	}
+	return nil
+}
+
+// JWTAuth validates signed RPCClaims tokens carried in RPCAuthHeader on inbound RPC control
+// messages (health, linkdefs.put, linkdefs.del, link health, config update, OTel config, shutdown), rejecting anything not signed by a
+// trusted issuer or whose claims don't match this provider. Note that this provider SDK doesn't
+// currently register a handler for linkdefs.get (see subToNats), so that topic isn't enforced
+// even though it's named in the lattice topic set.
+//
+// Load one from HostData with LoadJWTAuthFromHostData, which returns (nil, nil) when
+// HostData.RPCAuthEnabled is false; Wrap is a no-op on a nil *JWTAuth so provider.New can wire it
+// in unconditionally.
+type JWTAuth struct {
+	providerKey   string
+	latticePrefix string
+	logger        *slog.Logger
+
+	mu      sync.RWMutex
+	issuers map[string]nkeys.KeyPair // nkey-encoded issuer public key -> verify-only keypair
+}
+
+// LoadJWTAuthFromHostData builds a JWTAuth trusting hostData.ClusterIssuers and, if set,
+// hostData.RPCAuthJWTPublicKey, as RPC auth token issuers. Returns (nil, nil) when
+// hostData.RPCAuthEnabled is false, so existing unauthenticated deployments keep working.
+func LoadJWTAuthFromHostData(hostData HostData, logger *slog.Logger) (*JWTAuth, error) {
+	if !hostData.RPCAuthEnabled {
+		return nil, nil
+	}
+
+	trusted := append([]string{}, hostData.ClusterIssuers...)
+	if hostData.RPCAuthJWTPublicKey != "" {
+		trusted = append(trusted, hostData.RPCAuthJWTPublicKey)
+	}
+	if len(trusted) == 0 {
+		return nil, fmt.Errorf("rpc auth enabled but no trusted issuers: set cluster_issuers or rpc_auth_jwt_public_key")
+	}
+
+	auth := &JWTAuth{
+		providerKey:   hostData.ProviderKey,
+		latticePrefix: hostData.LatticeRPCPrefix,
+		logger:        logger,
+		issuers:       map[string]nkeys.KeyPair{},
+	}
+	if err := auth.setIssuers(trusted); err != nil {
+		return nil, err
+	}
+
+	return auth, nil
+}
+
+func (a *JWTAuth) setIssuers(publicKeys []string) error {
+	issuers := make(map[string]nkeys.KeyPair, len(publicKeys))
+	for _, pk := range publicKeys {
+		kp, err := nkeys.FromPublicKey(pk)
+		if err != nil {
+			return fmt.Errorf("invalid RPC auth issuer key %q: %w", pk, err)
+		}
+		issuers[pk] = kp
+	}
+
+	a.mu.Lock()
+	a.issuers = issuers
+	a.mu.Unlock()
+	return nil
+}
+
+// Wrap returns handler wrapped with token authentication: requests failing authentication are
+// rejected with a structured error response and never reach handler. Wrap is a no-op (returns
+// handler unchanged) on a nil *JWTAuth, so callers don't need a separate nil check.
+func (a *JWTAuth) Wrap(handler nats.MsgHandler) nats.MsgHandler {
+	if a == nil {
+		return handler
+	}
+	return func(m *nats.Msg) {
+		if err := a.authenticate(m); err != nil {
+			a.logger.Warn("rejected unauthenticated RPC message", "subject", m.Subject, slog.Any("error", err))
+			a.respondDenied(m, err)
+			return
+		}
+		handler(m)
+	}
+}
+
+func (a *JWTAuth) authenticate(m *nats.Msg) error {
+	var token string
+	if m.Header != nil {
+		token = m.Header.Get(RPCAuthHeader)
+	}
+	return a.authenticateToken(token)
+}
+
+// authenticateToken validates a signed RPC auth token already extracted from its transport
+// (the RPCAuthHeader NATS header for authenticate, or gRPC metadata for requireRPCAuth in
+// transport_grpc.go), so both transports share one verification path.
+func (a *JWTAuth) authenticateToken(token string) error {
+	if token == "" {
+		return fmt.Errorf("missing %s header", RPCAuthHeader)
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 2 {
+		return fmt.Errorf("malformed RPC auth token")
+	}
+	payload, sig := parts[0], parts[1]
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return fmt.Errorf("malformed RPC auth token payload: %w", err)
+	}
+	sigBytes, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("malformed RPC auth token signature: %w", err)
+	}
+
+	var claims RPCClaims
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return fmt.Errorf("malformed RPC auth token claims: %w", err)
+	}
+
+	a.mu.RLock()
+	issuer, ok := a.issuers[claims.Issuer]
+	a.mu.RUnlock()
+	if !ok {
+		return fmt.Errorf("untrusted token issuer %q", claims.Issuer)
+	}
+
+	if err := issuer.Verify([]byte(payload), sigBytes); err != nil {
+		return fmt.Errorf("invalid token signature: %w", err)
+	}
+
+	return claims.validate(a.providerKey, a.latticePrefix)
+}
+
+type rpcAuthDenied struct {
+	Error string `json:"error"`
+}
+
+func (a *JWTAuth) respondDenied(m *nats.Msg, reason error) {
+	if m.Reply == "" {
+		return
+	}
+	body, err := json.Marshal(rpcAuthDenied{Error: reason.Error()})
+	if err != nil {
+		return
+	}
+	if err := m.Respond(body); err != nil {
+		a.logger.Error("failed to publish RPC auth denial", slog.Any("error", err))
+	}
+}
+
+// jwksRotation is the payload expected on the RPC auth JWKS rotation subject: the full replacement
+// set of trusted issuer nkey public keys, conventionally published after a cluster issuer key is
+// rotated.
+type jwksRotation struct {
+	Issuers []string `json:"issuers"`
+}
+
+// SubscribeRotation listens on subject (conventionally Topics.LATTICE_RPC_AUTH_JWKS) for updated
+// issuer key material, atomically replacing the trusted issuer set on every message. Like every
+// other RPC control-plane subject, a rotation message must carry a RPCAuthHeader token signed by
+// one of the currently-trusted issuers (checked the same way Wrap checks it); otherwise anyone who
+// can publish to subject could replace the trusted issuer set outright, so this is enforced even
+// though SubscribeRotation's caller doesn't route it through Wrap itself.
+func (a *JWTAuth) SubscribeRotation(nc *nats.Conn, subject string) (*nats.Subscription, error) {
+	return nc.Subscribe(subject, func(m *nats.Msg) {
+		if err := a.authenticate(m); err != nil {
+			a.logger.Warn("rejected unauthenticated RPC auth JWKS rotation", slog.Any("error", err))
+			a.respondDenied(m, err)
+			return
+		}
+
+		var rotation jwksRotation
+		if err := json.Unmarshal(m.Data, &rotation); err != nil {
+			a.logger.Error("failed to decode RPC auth JWKS rotation", slog.Any("error", err))
+			return
+		}
+		if err := a.setIssuers(rotation.Issuers); err != nil {
+			a.logger.Error("failed to apply RPC auth JWKS rotation", slog.Any("error", err))
+		}
+	})
+}
@@ -1,8 +1,17 @@
 package provider
 
-// NOTE(brooksmtownsend): There might be a better way to represent this in Go, please comment
-// or leave an issue if you can think of one. Perhaps I could do the decryption during the
-// unmarshalling process, but I'm not sure if that would be a good idea.
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/nats-io/nkeys"
+)
+
+// linkWithEncryptedSecrets is the wire representation of a link definition as sent by the host:
+// secrets are serialized and encrypted into an opaque blob. InterfaceLinkDefinition.UnmarshalJSON
+// decrypts this shape into a usable map[string]SecretValue, so callers outside this package
+// should never need to reference linkWithEncryptedSecrets directly.
 type linkWithEncryptedSecrets struct {
 	SourceID      string            `json:"source_id,omitempty"`
 	Target        string            `json:"target,omitempty"`
@@ -29,3 +38,79 @@ type InterfaceLinkDefinition struct {
 	SourceSecrets map[string]SecretValue `json:"source_secrets,omitempty"`
 	TargetSecrets map[string]SecretValue `json:"target_secrets,omitempty"`
 }
+
+// secretsXKeyContext holds the keypair used to decrypt link secrets encountered during
+// InterfaceLinkDefinition.UnmarshalJSON. It's configured once per provider, by default from the
+// xkey seed advertised in HostData, or overridden with WithSecretsXKey.
+type secretsXKeyContext struct {
+	xkey   nkeys.KeyPair
+	sender string
+}
+
+var (
+	secretsXKeyMu  sync.RWMutex
+	secretsXKeyCtx *secretsXKeyContext
+)
+
+// setSecretsXKeyContext configures the xkey used to decrypt link secrets as they're unmarshaled.
+// It's called once from provider.New() with the default xkey, and again if WithSecretsXKey is
+// supplied as an option.
+func setSecretsXKeyContext(xkey nkeys.KeyPair, sender string) {
+	secretsXKeyMu.Lock()
+	defer secretsXKeyMu.Unlock()
+	secretsXKeyCtx = &secretsXKeyContext{xkey: xkey, sender: sender}
+}
+
+func getSecretsXKeyContext() *secretsXKeyContext {
+	secretsXKeyMu.RLock()
+	defer secretsXKeyMu.RUnlock()
+	return secretsXKeyCtx
+}
+
+// UnmarshalJSON decodes a link definition off the wire, transparently decrypting
+// SourceSecrets/TargetSecrets (if present) using the xkey configured via WithSecretsXKey (or the
+// provider's default xkey) so callers of SourceLinkPut/TargetLinkPut always see a ready-to-use
+// map[string]SecretValue. Link definitions with no encrypted secrets unmarshal exactly as before,
+// so tests and callers that pass plaintext link definitions are unaffected.
+//
+// If either side's secrets are one part of a chunked payload still awaiting more parts, this
+// returns an error wrapping ErrSecretsIncomplete (via DecryptSecrets) and leaves
+// l.SourceSecrets/l.TargetSecrets unset. Callers must check for that with errors.Is and must not
+// treat it as "this link has no secrets" — see the LATTICE_LINK_PUT handler in provider.go.
+func (l *InterfaceLinkDefinition) UnmarshalJSON(data []byte) error {
+	var wire linkWithEncryptedSecrets
+	if err := json.Unmarshal(data, &wire); err != nil {
+		return fmt.Errorf("failed to unmarshal link definition: %w", err)
+	}
+
+	l.SourceID = wire.SourceID
+	l.Target = wire.Target
+	l.Name = wire.Name
+	l.WitNamespace = wire.WitNamespace
+	l.WitPackage = wire.WitPackage
+	l.Interfaces = wire.Interfaces
+	l.SourceConfig = wire.SourceConfig
+	l.TargetConfig = wire.TargetConfig
+
+	if wire.SourceSecrets == nil && wire.TargetSecrets == nil {
+		return nil
+	}
+
+	ctx := getSecretsXKeyContext()
+	if ctx == nil {
+		return fmt.Errorf("cannot decrypt link secrets: no secrets xkey configured, see provider.WithSecretsXKey")
+	}
+
+	sourceSecrets, err := DecryptSecrets(wire.SourceSecrets, ctx.xkey, ctx.sender)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt source secrets: %w", err)
+	}
+	targetSecrets, err := DecryptSecrets(wire.TargetSecrets, ctx.xkey, ctx.sender)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt target secrets: %w", err)
+	}
+	l.SourceSecrets = sourceSecrets
+	l.TargetSecrets = targetSecrets
+
+	return nil
+}
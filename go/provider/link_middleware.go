@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+)
+
+// Phase identifies a stage of the link-lifecycle pipeline that putLink/deleteLink run around the
+// user's SourceLinkPut/TargetLinkPut/SourceLinkDel/TargetLinkDel handler. See OnPhase and Use.
+type Phase int
+
+const (
+	// PhaseValidate hooks run first, before the user's handler; a returned error refuses the
+	// link and the handler never runs.
+	PhaseValidate Phase = iota
+	// PhaseApply hooks run immediately after the user's handler succeeds.
+	PhaseApply
+	// PhaseCommit hooks run after PhaseApply; a returned error runs every PhaseRollback hook,
+	// in reverse registration order, against the same link definition.
+	PhaseCommit
+	// PhaseRollback hooks undo a PhaseApply or PhaseCommit failure. They never run on their own and their own
+	// errors are only logged, since by that point the link put/del is already failing.
+	PhaseRollback
+)
+
+func (p Phase) String() string {
+	switch p {
+	case PhaseValidate:
+		return "validate"
+	case PhaseApply:
+		return "apply"
+	case PhaseCommit:
+		return "commit"
+	case PhaseRollback:
+		return "rollback"
+	default:
+		return fmt.Sprintf("Phase(%d)", int(p))
+	}
+}
+
+// Hook is a single middleware step bound to a Phase with OnPhase.
+type Hook func(context.Context, InterfaceLinkDefinition) error
+
+// LinkMiddleware is a Hook awaiting registration via Use. Build one with OnPhase.
+type LinkMiddleware struct {
+	phase Phase
+	hook  Hook
+}
+
+// OnPhase builds a LinkMiddleware that runs fn during phase, letting provider authors implement
+// cross-cutting link lifecycle behavior (config schema validation, secret resolution, metrics)
+// without embedding it in every SourceLinkPut/TargetLinkPut/SourceLinkDel/TargetLinkDel handler.
+// Pass the result to Use to register it. Hooks for the same Phase run in the order they're passed
+// to Use, except PhaseRollback, which runs in reverse.
+func OnPhase(phase Phase, fn Hook) LinkMiddleware {
+	return LinkMiddleware{phase: phase, hook: fn}
+}
+
+// Use registers mw with the provider's link lifecycle pipeline, wired around every link put/del
+// by runLinkPipeline.
+func Use(mw ...LinkMiddleware) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		for _, m := range mw {
+			wp.linkMiddleware[m.phase] = append(wp.linkMiddleware[m.phase], m.hook)
+		}
+		return nil
+	}
+}
+
+// runLinkPipeline sequences wp.linkMiddleware's PhaseValidate/PhaseApply/PhaseCommit/
+// PhaseRollback hooks around handle, the user's SourceLinkPut/TargetLinkPut/SourceLinkDel/
+// TargetLinkDel handler for link l. A PhaseValidate error refuses the link before handle runs.
+// Once handle succeeds, a PhaseApply or PhaseCommit error runs every PhaseRollback hook, in
+// reverse registration order, against l.
+func (wp *WasmcloudProvider) runLinkPipeline(ctx context.Context, l InterfaceLinkDefinition, handle Hook) error {
+	for _, hook := range wp.linkMiddleware[PhaseValidate] {
+		if err := hook(ctx, l); err != nil {
+			return fmt.Errorf("link refused in %s phase: %w", PhaseValidate, err)
+		}
+	}
+
+	if err := handle(ctx, l); err != nil {
+		return err
+	}
+
+	for _, hook := range wp.linkMiddleware[PhaseApply] {
+		if err := hook(ctx, l); err != nil {
+			wp.rollbackLink(ctx, l)
+			return fmt.Errorf("link apply failed in %s phase: %w", PhaseApply, err)
+		}
+	}
+
+	for _, hook := range wp.linkMiddleware[PhaseCommit] {
+		if err := hook(ctx, l); err != nil {
+			wp.rollbackLink(ctx, l)
+			return fmt.Errorf("link commit failed in %s phase: %w", PhaseCommit, err)
+		}
+	}
+
+	return nil
+}
+
+// rollbackLink runs every PhaseRollback hook, in reverse registration order, against l. Hook
+// errors are logged rather than returned: rollback runs after the link put/del has already
+// failed, so there's no caller left to propagate a second error to.
+func (wp *WasmcloudProvider) rollbackLink(ctx context.Context, l InterfaceLinkDefinition) {
+	hooks := wp.linkMiddleware[PhaseRollback]
+	for i := len(hooks) - 1; i >= 0; i-- {
+		if err := hooks[i](ctx, l); err != nil {
+			wp.Logger.Error("link rollback hook failed", "error", err, "link", l)
+		}
+	}
+}
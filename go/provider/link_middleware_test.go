@@ -0,0 +1,100 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func newTestProviderForLinkPipeline() *WasmcloudProvider {
+	return &WasmcloudProvider{
+		Logger:         slog.New(slog.NewTextHandler(io.Discard, nil)),
+		linkMiddleware: make(map[Phase][]Hook),
+	}
+}
+
+func TestRunLinkPipelineValidateErrorRefusesLinkBeforeHandle(t *testing.T) {
+	wp := newTestProviderForLinkPipeline()
+	var handled bool
+	Use(OnPhase(PhaseValidate, func(context.Context, InterfaceLinkDefinition) error {
+		return errors.New("schema invalid")
+	}))(wp)
+
+	err := wp.runLinkPipeline(context.Background(), InterfaceLinkDefinition{}, func(context.Context, InterfaceLinkDefinition) error {
+		handled = true
+		return nil
+	})
+
+	if err == nil {
+		t.Fatal("expected a PhaseValidate error to refuse the link")
+	}
+	if handled {
+		t.Error("expected the link handler to never run after a PhaseValidate error")
+	}
+}
+
+func TestRunLinkPipelineCommitFailureRunsRollbackInReverseOrder(t *testing.T) {
+	wp := newTestProviderForLinkPipeline()
+	var order []string
+
+	Use(
+		OnPhase(PhaseRollback, func(context.Context, InterfaceLinkDefinition) error {
+			order = append(order, "rollback-1")
+			return nil
+		}),
+		OnPhase(PhaseRollback, func(context.Context, InterfaceLinkDefinition) error {
+			order = append(order, "rollback-2")
+			return nil
+		}),
+		OnPhase(PhaseCommit, func(context.Context, InterfaceLinkDefinition) error {
+			order = append(order, "commit")
+			return errors.New("commit failed")
+		}),
+	)(wp)
+
+	err := wp.runLinkPipeline(context.Background(), InterfaceLinkDefinition{}, func(context.Context, InterfaceLinkDefinition) error {
+		order = append(order, "handle")
+		return nil
+	})
+
+	if err == nil {
+		t.Fatal("expected a PhaseCommit error to be returned")
+	}
+
+	want := []string{"handle", "commit", "rollback-2", "rollback-1"}
+	if len(order) != len(want) {
+		t.Fatalf("got order %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("got order %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRunLinkPipelineSucceedsWithNoMiddleware(t *testing.T) {
+	wp := newTestProviderForLinkPipeline()
+
+	err := wp.runLinkPipeline(context.Background(), InterfaceLinkDefinition{}, func(context.Context, InterfaceLinkDefinition) error {
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestPhaseString(t *testing.T) {
+	tests := map[Phase]string{
+		PhaseValidate: "validate",
+		PhaseApply:    "apply",
+		PhaseCommit:   "commit",
+		PhaseRollback: "rollback",
+	}
+	for phase, want := range tests {
+		if got := phase.String(); got != want {
+			t.Errorf("Phase(%d).String() = %q, want %q", phase, got, want)
+		}
+	}
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"hash/crc32"
+	"math/rand"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+// LinkSelectionStrategy selects which of a provider's several source or target links
+// PickSourceLink/PickTargetLink returns, for providers that fan RPCs out across many linked
+// components (e.g. a messaging or HTTP-client provider with several downstreams).
+type LinkSelectionStrategy int
+
+const (
+	LinkSelectionRoundRobin LinkSelectionStrategy = iota
+	LinkSelectionRandom
+	LinkSelectionWeightedRandom
+	LinkSelectionLeastOutstandingRequests
+	LinkSelectionConsistentHash
+)
+
+// LinkSelectionOptions configures a single PickSourceLink/PickTargetLink call.
+type LinkSelectionOptions struct {
+	// Key picks a stable link under LinkSelectionConsistentHash, e.g. a session or tenant ID that
+	// should keep reaching the same downstream component as long as the link set doesn't change.
+	// Ignored by every other strategy.
+	Key string
+}
+
+// linkSelector holds the state PickSourceLink/PickTargetLink carry across calls for one side
+// (source or target) of a provider's links: a round-robin cursor, per-link outstanding-request
+// counts for LinkSelectionLeastOutstandingRequests, and the LinkSelectionWeightedRandom alias
+// table, rebuilt lazily the next time it's needed after a link put/del event invalidates it.
+type linkSelector struct {
+	roundRobin uint64
+
+	mu          sync.Mutex
+	outstanding map[string]*atomic.Int64
+	aliasDirty  bool
+	alias       *aliasTable
+}
+
+func newLinkSelector() *linkSelector {
+	return &linkSelector{outstanding: make(map[string]*atomic.Int64), aliasDirty: true}
+}
+
+// invalidateAlias marks the weighted-random alias table stale, so the next
+// LinkSelectionWeightedRandom pick rebuilds it from the link set at that time.
+func (s *linkSelector) invalidateAlias() {
+	s.mu.Lock()
+	s.aliasDirty = true
+	s.mu.Unlock()
+}
+
+// outstandingCounter returns (creating on first use) the in-flight call counter for key.
+func (s *linkSelector) outstandingCounter(key string) *atomic.Int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c, ok := s.outstanding[key]
+	if !ok {
+		c = &atomic.Int64{}
+		s.outstanding[key] = c
+	}
+	return c
+}
+
+// pick runs strategy over links, a snapshot the caller already took under wp.lock, keyed by
+// keyFunc (l.Target for source links, l.SourceID for target links). It returns false if links is
+// empty.
+func (s *linkSelector) pick(links []InterfaceLinkDefinition, keyFunc func(InterfaceLinkDefinition) string, strategy LinkSelectionStrategy, opts LinkSelectionOptions) (InterfaceLinkDefinition, bool) {
+	if len(links) == 0 {
+		return InterfaceLinkDefinition{}, false
+	}
+	// links comes from map iteration; sorting by key gives the round-robin cursor and the cached
+	// alias table the same order on every call.
+	sort.Slice(links, func(i, j int) bool { return keyFunc(links[i]) < keyFunc(links[j]) })
+
+	switch strategy {
+	case LinkSelectionRandom:
+		return links[rand.Intn(len(links))], true
+
+	case LinkSelectionWeightedRandom:
+		s.mu.Lock()
+		if s.aliasDirty || s.alias == nil || len(s.alias.prob) != len(links) {
+			s.alias = newAliasTable(links)
+			s.aliasDirty = false
+		}
+		alias := s.alias
+		s.mu.Unlock()
+		return links[alias.draw()], true
+
+	case LinkSelectionLeastOutstandingRequests:
+		best := links[0]
+		bestCount := s.outstandingCounter(keyFunc(best)).Load()
+		for _, l := range links[1:] {
+			if c := s.outstandingCounter(keyFunc(l)).Load(); c < bestCount {
+				best, bestCount = l, c
+			}
+		}
+		return best, true
+
+	case LinkSelectionConsistentHash:
+		hash := crc32.ChecksumIEEE([]byte(opts.Key))
+		return links[hash%uint32(len(links))], true
+
+	default: // LinkSelectionRoundRobin
+		i := atomic.AddUint64(&s.roundRobin, 1) - 1
+		return links[i%uint64(len(links))], true
+	}
+}
+
+// aliasTable implements Vose's alias method for weighted-random selection: O(n) to build, O(1) to
+// draw from. Weights come from each link's SourceConfig["weight"] (an integer or float string;
+// unset, non-positive, or unparseable defaults to 1, giving unweighted links equal odds).
+type aliasTable struct {
+	prob  []float64
+	alias []int
+}
+
+func newAliasTable(links []InterfaceLinkDefinition) *aliasTable {
+	n := len(links)
+	weights := make([]float64, n)
+	var total float64
+	for i, l := range links {
+		w := 1.0
+		if raw, ok := l.SourceConfig["weight"]; ok {
+			if parsed, err := strconv.ParseFloat(raw, 64); err == nil && parsed > 0 {
+				w = parsed
+			}
+		}
+		weights[i] = w
+		total += w
+	}
+
+	prob := make([]float64, n)
+	alias := make([]int, n)
+	scaled := make([]float64, n)
+	small := make([]int, 0, n)
+	large := make([]int, 0, n)
+	for i, w := range weights {
+		scaled[i] = w * float64(n) / total
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+
+		prob[s] = scaled[s]
+		alias[s] = l
+
+		scaled[l] = scaled[l] + scaled[s] - 1
+		if scaled[l] < 1 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	for _, i := range large {
+		prob[i] = 1
+	}
+	for _, i := range small {
+		prob[i] = 1
+	}
+
+	return &aliasTable{prob: prob, alias: alias}
+}
+
+// draw returns the index of one weighted pick.
+func (a *aliasTable) draw() int {
+	i := rand.Intn(len(a.prob))
+	if rand.Float64() < a.prob[i] {
+		return i
+	}
+	return a.alias[i]
+}
+
+// PickSourceLink selects one of the provider's source links (where this provider is the link's
+// source and each link names a different downstream component) using strategy. It returns false
+// if the provider currently has no source links. Pair LinkSelectionLeastOutstandingRequests with
+// TrackSourceLinkCall around the downstream call so outstanding counts stay accurate.
+func (wp *WasmcloudProvider) PickSourceLink(strategy LinkSelectionStrategy, opts LinkSelectionOptions) (InterfaceLinkDefinition, bool) {
+	wp.lock.Lock()
+	links := make([]InterfaceLinkDefinition, 0, len(wp.sourceLinks))
+	for _, l := range wp.sourceLinks {
+		links = append(links, l)
+	}
+	wp.lock.Unlock()
+
+	return wp.sourceLinkSelector.pick(links, func(l InterfaceLinkDefinition) string { return l.Target }, strategy, opts)
+}
+
+// PickTargetLink selects one of the provider's target links (where this provider is the link's
+// target and each link names a different upstream source component) using strategy. It returns
+// false if the provider currently has no target links. Pair LinkSelectionLeastOutstandingRequests
+// with TrackTargetLinkCall around the call back to that source so outstanding counts stay
+// accurate.
+func (wp *WasmcloudProvider) PickTargetLink(strategy LinkSelectionStrategy, opts LinkSelectionOptions) (InterfaceLinkDefinition, bool) {
+	wp.lock.Lock()
+	links := make([]InterfaceLinkDefinition, 0, len(wp.targetLinks))
+	for _, l := range wp.targetLinks {
+		links = append(links, l)
+	}
+	wp.lock.Unlock()
+
+	return wp.targetLinkSelector.pick(links, func(l InterfaceLinkDefinition) string { return l.SourceID }, strategy, opts)
+}
+
+// TrackSourceLinkCall marks a call to link's downstream component as in flight for
+// LinkSelectionLeastOutstandingRequests, returning a func to call when the call completes. It's
+// cheap to call unconditionally; the count it maintains is simply unused by other strategies.
+func (wp *WasmcloudProvider) TrackSourceLinkCall(link InterfaceLinkDefinition) func() {
+	counter := wp.sourceLinkSelector.outstandingCounter(link.Target)
+	counter.Add(1)
+	return func() { counter.Add(-1) }
+}
+
+// TrackTargetLinkCall marks a call back to link's source component as in flight for
+// LinkSelectionLeastOutstandingRequests, returning a func to call when the call completes. It's
+// cheap to call unconditionally; the count it maintains is simply unused by other strategies.
+func (wp *WasmcloudProvider) TrackTargetLinkCall(link InterfaceLinkDefinition) func() {
+	counter := wp.targetLinkSelector.outstandingCounter(link.SourceID)
+	counter.Add(1)
+	return func() { counter.Add(-1) }
+}
+
+// registerLinkSelectionInvalidation subscribes sourceLinkSelector and targetLinkSelector's
+// weighted-random alias tables to be invalidated on every link put/del, so the first
+// LinkSelectionWeightedRandom pick after the event is delivered rebuilds from the current link set
+// and SourceConfig["weight"] values. The EventBus delivers asynchronously, so picks made between a
+// put/del and that delivery still draw from the previous table. Subscribing (rather than rebuilding inline in putLink/deleteLink) keeps linkselect.go
+// independent of the link lifecycle's own locking.
+func (wp *WasmcloudProvider) registerLinkSelectionInvalidation() {
+	invalidate := func(context.Context, Event) error {
+		wp.sourceLinkSelector.invalidateAlias()
+		wp.targetLinkSelector.invalidateAlias()
+		return nil
+	}
+	wp.events.Subscribe(EventLinkPut, invalidate)
+	wp.events.Subscribe(EventLinkDel, invalidate)
+}
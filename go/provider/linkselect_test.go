@@ -0,0 +1,127 @@
+package provider
+
+import (
+	"io"
+	"log/slog"
+	"testing"
+)
+
+func newTestProviderForLinkSelection() *WasmcloudProvider {
+	wp := &WasmcloudProvider{
+		Logger:             slog.New(slog.NewTextHandler(io.Discard, nil)),
+		sourceLinks:        make(map[string]InterfaceLinkDefinition),
+		targetLinks:        make(map[string]InterfaceLinkDefinition),
+		sourceLinkSelector: newLinkSelector(),
+		targetLinkSelector: newLinkSelector(),
+	}
+	wp.events = newEventBus(wp.Logger)
+	wp.registerLinkSelectionInvalidation()
+	return wp
+}
+
+func TestPickSourceLinkReturnsFalseWithNoLinks(t *testing.T) {
+	wp := newTestProviderForLinkSelection()
+	if _, ok := wp.PickSourceLink(LinkSelectionRoundRobin, LinkSelectionOptions{}); ok {
+		t.Error("expected PickSourceLink to return false with no source links")
+	}
+}
+
+func TestPickSourceLinkRoundRobinCyclesThroughEveryLink(t *testing.T) {
+	wp := newTestProviderForLinkSelection()
+	wp.sourceLinks["a"] = InterfaceLinkDefinition{Target: "a"}
+	wp.sourceLinks["b"] = InterfaceLinkDefinition{Target: "b"}
+
+	seen := map[string]int{}
+	for i := 0; i < 4; i++ {
+		link, ok := wp.PickSourceLink(LinkSelectionRoundRobin, LinkSelectionOptions{})
+		if !ok {
+			t.Fatal("expected a link")
+		}
+		seen[link.Target]++
+	}
+
+	if seen["a"] != 2 || seen["b"] != 2 {
+		t.Fatalf("expected round-robin to split evenly, got %v", seen)
+	}
+}
+
+func TestPickSourceLinkConsistentHashIsStableForTheSameKey(t *testing.T) {
+	wp := newTestProviderForLinkSelection()
+	wp.sourceLinks["a"] = InterfaceLinkDefinition{Target: "a"}
+	wp.sourceLinks["b"] = InterfaceLinkDefinition{Target: "b"}
+	wp.sourceLinks["c"] = InterfaceLinkDefinition{Target: "c"}
+
+	first, ok := wp.PickSourceLink(LinkSelectionConsistentHash, LinkSelectionOptions{Key: "tenant-42"})
+	if !ok {
+		t.Fatal("expected a link")
+	}
+	for i := 0; i < 10; i++ {
+		again, ok := wp.PickSourceLink(LinkSelectionConsistentHash, LinkSelectionOptions{Key: "tenant-42"})
+		if !ok || again.Target != first.Target {
+			t.Fatalf("expected the same key to always pick %q, got %q", first.Target, again.Target)
+		}
+	}
+}
+
+func TestPickSourceLinkWeightedRandomFavorsHigherWeight(t *testing.T) {
+	wp := newTestProviderForLinkSelection()
+	wp.sourceLinks["heavy"] = InterfaceLinkDefinition{Target: "heavy", SourceConfig: map[string]string{"weight": "99"}}
+	wp.sourceLinks["light"] = InterfaceLinkDefinition{Target: "light", SourceConfig: map[string]string{"weight": "1"}}
+
+	counts := map[string]int{}
+	for i := 0; i < 500; i++ {
+		link, ok := wp.PickSourceLink(LinkSelectionWeightedRandom, LinkSelectionOptions{})
+		if !ok {
+			t.Fatal("expected a link")
+		}
+		counts[link.Target]++
+	}
+
+	if counts["heavy"] <= counts["light"] {
+		t.Fatalf("expected the weight-99 link to be picked far more often, got %v", counts)
+	}
+}
+
+func TestPickSourceLinkLeastOutstandingRequestsPicksTheIdleLink(t *testing.T) {
+	wp := newTestProviderForLinkSelection()
+	busy := InterfaceLinkDefinition{Target: "busy"}
+	idle := InterfaceLinkDefinition{Target: "idle"}
+	wp.sourceLinks["busy"] = busy
+	wp.sourceLinks["idle"] = idle
+
+	done := wp.TrackSourceLinkCall(busy)
+	defer done()
+
+	link, ok := wp.PickSourceLink(LinkSelectionLeastOutstandingRequests, LinkSelectionOptions{})
+	if !ok {
+		t.Fatal("expected a link")
+	}
+	if link.Target != "idle" {
+		t.Fatalf("expected the idle link to be picked, got %q", link.Target)
+	}
+}
+
+func TestPickTargetLinkUsesSourceIDAsTheLinkKey(t *testing.T) {
+	wp := newTestProviderForLinkSelection()
+	wp.targetLinks["source-a"] = InterfaceLinkDefinition{SourceID: "source-a"}
+
+	link, ok := wp.PickTargetLink(LinkSelectionRoundRobin, LinkSelectionOptions{})
+	if !ok || link.SourceID != "source-a" {
+		t.Fatalf("expected the link keyed by SourceID \"source-a\", got %+v, ok=%v", link, ok)
+	}
+}
+
+func TestLinkSelectorInvalidateAliasForcesARebuild(t *testing.T) {
+	s := newLinkSelector()
+	links := []InterfaceLinkDefinition{{Target: "a"}, {Target: "b"}}
+
+	keyFunc := func(l InterfaceLinkDefinition) string { return l.Target }
+	s.pick(links, keyFunc, LinkSelectionWeightedRandom, LinkSelectionOptions{})
+	built := s.alias
+
+	s.invalidateAlias()
+	s.pick(links, keyFunc, LinkSelectionWeightedRandom, LinkSelectionOptions{})
+	if s.alias == built {
+		t.Error("expected invalidateAlias to force the next weighted-random pick to rebuild the table")
+	}
+}
@@ -0,0 +1,244 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// LogFormat selects the stderr handler LoggerFor builds a named logger's config from.
+type LogFormat string
+
+const (
+	LogFormatJSON   LogFormat = "json"
+	LogFormatText   LogFormat = "text"
+	LogFormatLogfmt LogFormat = "logfmt"
+)
+
+// LoggerConfig configures the logging subsystem WithLogger installs in place of provider.New's
+// default handler (built from HostData.StructuredLogging and HostData.LogLevel).
+type LoggerConfig struct {
+	// Format selects the stderr handler: LogFormatJSON, LogFormatText, or LogFormatLogfmt. Empty
+	// defaults to LogFormatText.
+	Format LogFormat
+	// Level is the minimum level for a logger name not matched by Levels.
+	Level Level
+	// Levels overrides Level per logger name passed to LoggerFor, e.g. {"nats": Warn, "wrpc":
+	// Debug}. A name not present here falls back to Level.
+	Levels map[string]Level
+	// SampleRate, if greater than 1, emits only 1 in SampleRate records at Info level or below;
+	// Warn/Error/Critical records always pass through. Zero or 1 disables sampling.
+	SampleRate int
+	// OtelLoggerName is the name LoggerFor's OTEL fan-out handler requests from the global
+	// LoggerProvider SetupOtel installed (see go.opentelemetry.io/otel/log/global). Defaults to
+	// "wasmcloud-provider" if empty.
+	OtelLoggerName string
+}
+
+// WithLogger replaces wp.Logger (and every subsequent LoggerFor call) with one built from config:
+// a configurable stderr format (including a logfmt backend), per-logger-name minimum levels,
+// sampling for high-volume records, and a fan-out to the OTEL LoggerProvider SetupOtel already
+// installed, alongside stderr. Call wp.LoggerFor(name) afterwards for a *slog.Logger whose
+// minimum level reflects config.Levels[name].
+func WithLogger(config LoggerConfig) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		if config.Format == "" {
+			config.Format = LogFormatText
+		}
+		if config.Level == "" {
+			config.Level = Info
+		}
+		if config.OtelLoggerName == "" {
+			config.OtelLoggerName = "wasmcloud-provider"
+		}
+		wp.loggerConfig = &config
+		wp.Logger = wp.LoggerFor("default")
+		return nil
+	}
+}
+
+// LoggerFor returns a *slog.Logger for subsystem/package name, built from wp.loggerConfig (set via
+// WithLogger) if one was configured, or wp.Logger otherwise. Every call with the same name
+// produces a logger with the same format, minimum level, sampling, and OTEL fan-out, so callers
+// don't each need to rebuild that config by hand.
+func (wp *WasmcloudProvider) LoggerFor(name string) *slog.Logger {
+	if wp.loggerConfig == nil {
+		return wp.Logger.With("logger", name)
+	}
+	config := wp.loggerConfig
+
+	level, ok := config.Levels[name]
+	if !ok {
+		level = config.Level
+	}
+
+	var handler slog.Handler = newFanoutLogHandler([]slog.Handler{
+		newFormatHandler(config.Format, level.Level()),
+		&otelGlobalLogHandler{logger: global.Logger(config.OtelLoggerName)},
+	})
+	// otelGlobalLogHandler.Enabled is always true (see its doc comment), so the fan-out's own
+	// Enabled would report true at any level as long as OTEL logs are wired up; gate the whole
+	// chain on level explicitly so a Levels override still applies to every handler in it.
+	handler = &levelGateHandler{next: handler, level: level.Level()}
+	if config.SampleRate > 1 {
+		handler = newSamplingLogHandler(handler, config.SampleRate)
+	}
+
+	return slog.New(handler).With("logger", name)
+}
+
+// newFormatHandler builds the stderr slog.Handler for format at the given minimum level.
+func newFormatHandler(format LogFormat, level slog.Level) slog.Handler {
+	switch format {
+	case LogFormatJSON:
+		return slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	case LogFormatLogfmt:
+		return newLogfmtHandler(os.Stderr, level)
+	default:
+		return slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level})
+	}
+}
+
+// logfmtHandler is a minimal slog.Handler writing "key=value" formatted log lines, the format
+// LogFormatLogfmt selects as a third stderr backend alongside the built-in JSON/text handlers,
+// matching what tools like Prometheus, Consul, and Loki expect by default.
+type logfmtHandler struct {
+	writer io.Writer
+	level  slog.Level
+	attrs  []slog.Attr
+	groups []string
+}
+
+func newLogfmtHandler(w io.Writer, level slog.Level) *logfmtHandler {
+	return &logfmtHandler{writer: w, level: level}
+}
+
+func (h *logfmtHandler) Enabled(_ context.Context, level slog.Level) bool { return level >= h.level }
+
+func (h *logfmtHandler) Handle(_ context.Context, record slog.Record) error {
+	var line strings.Builder
+	fmt.Fprintf(&line, "time=%s level=%s msg=%q", record.Time.Format(time.RFC3339), record.Level.String(), record.Message)
+	writeAttr := func(a slog.Attr) bool {
+		fmt.Fprintf(&line, " %s=%q", strings.Join(append(h.groups, a.Key), "."), a.Value.String())
+		return true
+	}
+	for _, a := range h.attrs {
+		writeAttr(a)
+	}
+	record.Attrs(writeAttr)
+	line.WriteByte('\n')
+	_, err := io.WriteString(h.writer, line.String())
+	return err
+}
+
+func (h *logfmtHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &logfmtHandler{writer: h.writer, level: h.level, attrs: append(append([]slog.Attr{}, h.attrs...), attrs...), groups: h.groups}
+}
+
+func (h *logfmtHandler) WithGroup(name string) slog.Handler {
+	return &logfmtHandler{writer: h.writer, level: h.level, attrs: h.attrs, groups: append(append([]string{}, h.groups...), name)}
+}
+
+// otelGlobalLogHandler re-emits records through name's otel log.Logger sourced from the global
+// LoggerProvider SetupOtel installed (see go.opentelemetry.io/otel/log/global), so WithLogger's
+// fan-out reaches the same OTLP pipeline as the rest of the provider's telemetry without owning a
+// dedicated exporter the way logsinks.go's OTLPLogSink does. It behaves as a no-op until
+// SetupOtel's OtelConfig enables logs; global.Logger returns a no-op Logger until then.
+type otelGlobalLogHandler struct {
+	logger otellog.Logger
+	attrs  []otellog.KeyValue
+}
+
+func (h *otelGlobalLogHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *otelGlobalLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var r otellog.Record
+	r.SetTimestamp(record.Time)
+	r.SetBody(otellog.StringValue(record.Message))
+	r.SetSeverity(otelLogSeverity(record.Level))
+	r.AddAttributes(h.attrs...)
+	record.Attrs(func(a slog.Attr) bool {
+		r.AddAttributes(otellog.KeyValue{Key: a.Key, Value: otellog.StringValue(a.Value.String())})
+		return true
+	})
+	h.logger.Emit(ctx, r)
+	return nil
+}
+
+func (h *otelGlobalLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := append([]otellog.KeyValue{}, h.attrs...)
+	for _, a := range attrs {
+		next = append(next, otellog.KeyValue{Key: a.Key, Value: otellog.StringValue(a.Value.String())})
+	}
+	return &otelGlobalLogHandler{logger: h.logger, attrs: next}
+}
+
+func (h *otelGlobalLogHandler) WithGroup(string) slog.Handler { return h }
+
+// levelGateHandler enforces level as a minimum across every handler in next, including ones like
+// otelGlobalLogHandler whose own Enabled always returns true regardless of record level. LoggerFor
+// wraps its fan-out in one of these so a Levels override gates every output for that name, not
+// just the handlers that happen to check level themselves.
+type levelGateHandler struct {
+	next  slog.Handler
+	level slog.Level
+}
+
+func (h *levelGateHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return level >= h.level && h.next.Enabled(ctx, level)
+}
+
+func (h *levelGateHandler) Handle(ctx context.Context, record slog.Record) error {
+	return h.next.Handle(ctx, record)
+}
+
+func (h *levelGateHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &levelGateHandler{next: h.next.WithAttrs(attrs), level: h.level}
+}
+
+func (h *levelGateHandler) WithGroup(name string) slog.Handler {
+	return &levelGateHandler{next: h.next.WithGroup(name), level: h.level}
+}
+
+// samplingLogHandler drops a fraction of high-volume records (Info level and below) to reduce log
+// volume, while always emitting Warn/Error/Critical records. It keeps every SampleRate-th record
+// rather than a randomly chosen one, so sampled output stays reproducible across runs.
+type samplingLogHandler struct {
+	next       slog.Handler
+	sampleRate int
+	counter    atomic.Uint64
+}
+
+func newSamplingLogHandler(next slog.Handler, sampleRate int) *samplingLogHandler {
+	return &samplingLogHandler{next: next, sampleRate: sampleRate}
+}
+
+func (h *samplingLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *samplingLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	if record.Level >= slog.LevelWarn {
+		return h.next.Handle(ctx, record)
+	}
+	if h.counter.Add(1)%uint64(h.sampleRate) != 0 {
+		return nil
+	}
+	return h.next.Handle(ctx, record)
+}
+
+func (h *samplingLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingLogHandler{next: h.next.WithAttrs(attrs), sampleRate: h.sampleRate}
+}
+
+func (h *samplingLogHandler) WithGroup(name string) slog.Handler {
+	return &samplingLogHandler{next: h.next.WithGroup(name), sampleRate: h.sampleRate}
+}
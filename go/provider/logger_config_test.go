@@ -0,0 +1,98 @@
+package provider
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSamplingLogHandlerKeepsEveryNthRecordBelowWarn(t *testing.T) {
+	inner := &recordingHandler{enabled: true}
+	handler := newSamplingLogHandler(inner, 3)
+
+	for i := 0; i < 9; i++ {
+		record := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+		if err := handler.Handle(context.Background(), record); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+	}
+
+	if len(inner.records) != 3 {
+		t.Fatalf("expected 1 in 3 info records to pass through, got %d", len(inner.records))
+	}
+}
+
+func TestSamplingLogHandlerAlwaysEmitsWarnAndAbove(t *testing.T) {
+	inner := &recordingHandler{enabled: true}
+	handler := newSamplingLogHandler(inner, 100)
+
+	for i := 0; i < 5; i++ {
+		record := slog.NewRecord(time.Now(), slog.LevelWarn, "uh oh", 0)
+		if err := handler.Handle(context.Background(), record); err != nil {
+			t.Fatalf("Handle returned error: %v", err)
+		}
+	}
+
+	if len(inner.records) != 5 {
+		t.Fatalf("expected every warn record to pass through, got %d", len(inner.records))
+	}
+}
+
+func TestLogfmtHandlerFormatsKeyValuePairs(t *testing.T) {
+	var buf stringWriter
+	handler := newLogfmtHandler(&buf, slog.LevelInfo)
+	handler = handler.WithAttrs([]slog.Attr{slog.String("component", "test")}).(*logfmtHandler)
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "hello world", 0)
+	record.AddAttrs(slog.Int("count", 3))
+	if err := handler.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	got := buf.String()
+	for _, want := range []string{`msg="hello world"`, `component="test"`, `count="3"`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("logfmt output %q missing %q", got, want)
+		}
+	}
+}
+
+func TestLoggerForAppliesPerNameLevel(t *testing.T) {
+	wp := &WasmcloudProvider{Logger: slog.New(slog.NewTextHandler(&stringWriter{}, nil))}
+	err := WithLogger(LoggerConfig{
+		Format: LogFormatJSON,
+		Level:  Info,
+		Levels: map[string]Level{"nats": Warn},
+	})(wp)
+	if err != nil {
+		t.Fatalf("WithLogger returned error: %v", err)
+	}
+
+	natsLogger := wp.LoggerFor("nats")
+	if natsLogger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("expected the \"nats\" logger's Info level to be disabled by its Levels override")
+	}
+	if !natsLogger.Enabled(context.Background(), slog.LevelWarn) {
+		t.Error("expected the \"nats\" logger's Warn level to remain enabled")
+	}
+
+	defaultLogger := wp.LoggerFor("default")
+	if !defaultLogger.Enabled(context.Background(), slog.LevelInfo) {
+		t.Error("expected a logger name with no Levels override to fall back to config.Level")
+	}
+}
+
+// stringWriter is a minimal io.Writer for asserting what a handler wrote, without pulling in
+// bytes.Buffer just for these tests.
+type stringWriter struct {
+	data string
+}
+
+func (w *stringWriter) Write(p []byte) (int, error) {
+	w.data += string(p)
+	return len(p), nil
+}
+
+func (w *stringWriter) String() string { return w.data }
@@ -32,7 +32,12 @@ func (l Level) Level() slog.Level {
 		return slog.LevelInfo
 	case Debug:
 		return slog.LevelDebug
-	// NOTE: slog doesn't have trace/critical levels so we map them to debug/error
+	// slog doesn't have trace/critical levels, and HostData.LogLevel is only ever used as a
+	// minimum-severity threshold (see provider.New), never as a level a record is logged at, so
+	// mapping these to anything other than the adjacent built-in level would only make the
+	// "critical" setting hide logs instead of narrowing them. Sinks that need to tell Trace/Critical
+	// apart per record (e.g. logsinks.go's syslog severity mapping) can't recover that distinction
+	// from a record's slog.Level, since nothing in this package ever logs at a non-built-in level.
 	case Trace:
 		return slog.LevelDebug
 	case Critical:
@@ -0,0 +1,397 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"log/syslog"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
+	otellog "go.opentelemetry.io/otel/log"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+)
+
+// LogSink builds an additional slog.Handler for WithLogSinks to fan log records out to, alongside
+// whatever handler the provider's HostData-driven logger already uses, and a shutdown func (nil
+// if nothing needs flushing or closing) that WasmcloudProvider.Shutdown runs to drain it.
+type LogSink func() (slog.Handler, func(context.Context) error, error)
+
+// WithLogSinks fans every log record the provider emits out to each of sinks, in addition to the
+// existing stderr JSON/text handler built from HostData.StructuredLogging and HostData.LogLevel.
+// Each sink's shutdown func (if any) is registered alongside the other internal shutdown hooks
+// (e.g. OtelManager.Shutdown, see SetupOtel), so sinks are flushed as part of WasmcloudProvider's
+// ordinary Shutdown sequence. provider.New also builds sinks from WASMCLOUD_LOG_SINKS, ahead of
+// any passed here; see logSinksFromEnv.
+func WithLogSinks(sinks ...LogSink) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		handlers := []slog.Handler{wp.Logger.Handler()}
+		for _, sink := range sinks {
+			handler, shutdown, err := sink()
+			if err != nil {
+				return fmt.Errorf("failed to build log sink: %w", err)
+			}
+			handlers = append(handlers, handler)
+			if shutdown != nil {
+				wp.internalShutdownFuncs = append(wp.internalShutdownFuncs, shutdown)
+			}
+		}
+		wp.Logger = slog.New(newFanoutLogHandler(handlers))
+		return nil
+	}
+}
+
+// fanoutLogHandler is a slog.Handler that forwards every record to each of a fixed list of
+// handlers, continuing past the first error so one broken sink (e.g. a collector that's down)
+// doesn't silence the others.
+type fanoutLogHandler struct {
+	handlers []slog.Handler
+}
+
+func newFanoutLogHandler(handlers []slog.Handler) *fanoutLogHandler {
+	return &fanoutLogHandler{handlers: handlers}
+}
+
+func (h *fanoutLogHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, handler := range h.handlers {
+		if handler.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *fanoutLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var errs []error
+	for _, handler := range h.handlers {
+		if !handler.Enabled(ctx, record.Level) {
+			continue
+		}
+		if err := handler.Handle(ctx, record.Clone()); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *fanoutLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		next[i] = handler.WithAttrs(attrs)
+	}
+	return newFanoutLogHandler(next)
+}
+
+func (h *fanoutLogHandler) WithGroup(name string) slog.Handler {
+	next := make([]slog.Handler, len(h.handlers))
+	for i, handler := range h.handlers {
+		next[i] = handler.WithGroup(name)
+	}
+	return newFanoutLogHandler(next)
+}
+
+// syslogFacilities maps the facility names accepted by SyslogSink and the "facility" query
+// parameter of a syslog:// WASMCLOUD_LOG_SINKS URI to their syslog.Priority bits.
+var syslogFacilities = map[string]syslog.Priority{
+	"kern":     syslog.LOG_KERN,
+	"user":     syslog.LOG_USER,
+	"mail":     syslog.LOG_MAIL,
+	"daemon":   syslog.LOG_DAEMON,
+	"auth":     syslog.LOG_AUTH,
+	"syslog":   syslog.LOG_SYSLOG,
+	"lpr":      syslog.LOG_LPR,
+	"news":     syslog.LOG_NEWS,
+	"uucp":     syslog.LOG_UUCP,
+	"cron":     syslog.LOG_CRON,
+	"authpriv": syslog.LOG_AUTHPRIV,
+	"ftp":      syslog.LOG_FTP,
+	"local0":   syslog.LOG_LOCAL0,
+	"local1":   syslog.LOG_LOCAL1,
+	"local2":   syslog.LOG_LOCAL2,
+	"local3":   syslog.LOG_LOCAL3,
+	"local4":   syslog.LOG_LOCAL4,
+	"local5":   syslog.LOG_LOCAL5,
+	"local6":   syslog.LOG_LOCAL6,
+	"local7":   syslog.LOG_LOCAL7,
+}
+
+// SyslogSink ships every log record to a syslog daemon over network (e.g. "udp", "tcp") at addr,
+// tagged with the given facility (e.g. "local0", "daemon"; see syslogFacilities for the full
+// list). The syslog severity for each record is derived from its slog.Level via syslogSeverity.
+func SyslogSink(network, addr, facility string) LogSink {
+	return func() (slog.Handler, func(context.Context) error, error) {
+		priority, ok := syslogFacilities[strings.ToLower(facility)]
+		if !ok {
+			return nil, nil, fmt.Errorf("unknown syslog facility %q", facility)
+		}
+
+		writer, err := syslog.Dial(network, addr, priority|syslog.LOG_INFO, "wasmcloud-provider")
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to dial syslog at %s://%s: %w", network, addr, err)
+		}
+
+		handler := &syslogHandler{writer: writer}
+		return handler, func(context.Context) error { return writer.Close() }, nil
+	}
+}
+
+// syslogSeverity maps a slog.Level onto the closest standard syslog severity. Records below
+// slog.LevelDebug or at/above slog.LevelError+4 are treated as the repo's Trace and Critical
+// Level values would be (severity 7 and 2 respectively, see Level.Level), in case a caller logs
+// through slog.Logger.Log with an explicit non-built-in level instead of the usual
+// Debug/Info/Warn/Error methods.
+func syslogSeverity(level slog.Level) syslog.Priority {
+	switch {
+	case level < slog.LevelInfo:
+		return syslog.LOG_DEBUG
+	case level < slog.LevelWarn:
+		return syslog.LOG_INFO
+	case level < slog.LevelError:
+		return syslog.LOG_WARNING
+	case level < slog.LevelError+4:
+		return syslog.LOG_ERR
+	default:
+		return syslog.LOG_CRIT
+	}
+}
+
+// syslogHandler is a minimal slog.Handler writing "key=value" formatted records to a
+// *syslog.Writer at the severity syslogSeverity derives from the record's level.
+type syslogHandler struct {
+	writer *syslog.Writer
+	attrs  []slog.Attr
+	groups []string
+}
+
+func (h *syslogHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *syslogHandler) Handle(_ context.Context, record slog.Record) error {
+	var line strings.Builder
+	line.WriteString(record.Message)
+	writeAttr := func(a slog.Attr) bool {
+		fmt.Fprintf(&line, " %s=%q", strings.Join(append(h.groups, a.Key), "."), a.Value.String())
+		return true
+	}
+	for _, a := range h.attrs {
+		writeAttr(a)
+	}
+	record.Attrs(writeAttr)
+
+	msg := line.String()
+	switch syslogSeverity(record.Level) {
+	case syslog.LOG_DEBUG:
+		return h.writer.Debug(msg)
+	case syslog.LOG_INFO:
+		return h.writer.Info(msg)
+	case syslog.LOG_WARNING:
+		return h.writer.Warning(msg)
+	case syslog.LOG_ERR:
+		return h.writer.Err(msg)
+	default:
+		return h.writer.Crit(msg)
+	}
+}
+
+func (h *syslogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &syslogHandler{writer: h.writer, attrs: append(append([]slog.Attr{}, h.attrs...), attrs...), groups: h.groups}
+}
+
+func (h *syslogHandler) WithGroup(name string) slog.Handler {
+	return &syslogHandler{writer: h.writer, attrs: h.attrs, groups: append(append([]string{}, h.groups...), name)}
+}
+
+// JSONFileSink writes NDJSON log records to path, rotating it the same way the OTel file exporter
+// does (see newOtelFileWriter/rotatingFileWriter): once it exceeds rotateBytes, the active file is
+// renamed aside and a fresh one started, keeping at most rotateKeep of the renamed files. A zero
+// rotateBytes disables size-based rotation, and a zero rotateKeep keeps every renamed file. Sharing rotatingFileWriter with the
+// OTel file exporter means a JSONFileSink and an OtelConfig file:// endpoint pointed at the same
+// path share one writer instead of racing.
+func JSONFileSink(path string, rotateBytes int64, rotateKeep int) LogSink {
+	return func() (slog.Handler, func(context.Context) error, error) {
+		writer, err := acquireOtelFileWriter(path, rotateBytes, 0, rotateKeep)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to open JSON log file %q: %w", path, err)
+		}
+		handler := slog.NewJSONHandler(writer, nil)
+		return handler, func(context.Context) error { return releaseOtelFileWriter(path) }, nil
+	}
+}
+
+// OTLPLogSink ships every log record to an OTLP log collector at endpoint (gRPC by default; use
+// "otlp+http://" to select the HTTP/protobuf exporter instead), with headers attached to every
+// export request, e.g. for collector auth.
+func OTLPLogSink(endpoint string, headers map[string]string) LogSink {
+	return func() (slog.Handler, func(context.Context) error, error) {
+		ctx := context.Background()
+
+		var exporter sdklog.Exporter
+		var err error
+		if strings.HasPrefix(endpoint, "otlp+http://") || strings.HasPrefix(endpoint, "otlp+https://") {
+			opts := []otlploghttp.Option{otlploghttp.WithEndpointURL(strings.Replace(endpoint, "otlp+", "", 1))}
+			if len(headers) > 0 {
+				opts = append(opts, otlploghttp.WithHeaders(headers))
+			}
+			exporter, err = otlploghttp.New(ctx, opts...)
+		} else {
+			opts := []otlploggrpc.Option{otlploggrpc.WithEndpointURL(endpoint)}
+			if len(headers) > 0 {
+				opts = append(opts, otlploggrpc.WithHeaders(headers))
+			}
+			exporter, err = otlploggrpc.New(ctx, opts...)
+		}
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create OTLP log exporter for %q: %w", endpoint, err)
+		}
+
+		processor := sdklog.NewBatchProcessor(exporter, sdklog.WithExportInterval(OtelLogExportInterval))
+		loggerProvider := sdklog.NewLoggerProvider(sdklog.WithProcessor(processor))
+		logger := loggerProvider.Logger("wasmcloud-provider-log-sink")
+
+		handler := &otlpLogHandler{logger: logger}
+		return handler, loggerProvider.Shutdown, nil
+	}
+}
+
+// otlpLogHandler is a minimal slog.Handler that re-emits every record through an otel
+// log.Logger, translating slog's {Time, Level, Message, Attrs} into the equivalent otel log.Record
+// fields.
+type otlpLogHandler struct {
+	logger otellog.Logger
+	attrs  []otellog.KeyValue
+}
+
+func (h *otlpLogHandler) Enabled(context.Context, slog.Level) bool { return true }
+
+func (h *otlpLogHandler) Handle(ctx context.Context, record slog.Record) error {
+	var r otellog.Record
+	r.SetTimestamp(record.Time)
+	r.SetBody(otellog.StringValue(record.Message))
+	r.SetSeverity(otelLogSeverity(record.Level))
+	r.AddAttributes(h.attrs...)
+	record.Attrs(func(a slog.Attr) bool {
+		r.AddAttributes(otellog.KeyValue{Key: a.Key, Value: otellog.StringValue(a.Value.String())})
+		return true
+	})
+	h.logger.Emit(ctx, r)
+	return nil
+}
+
+func (h *otlpLogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	next := append([]otellog.KeyValue{}, h.attrs...)
+	for _, a := range attrs {
+		next = append(next, otellog.KeyValue{Key: a.Key, Value: otellog.StringValue(a.Value.String())})
+	}
+	return &otlpLogHandler{logger: h.logger, attrs: next}
+}
+
+func (h *otlpLogHandler) WithGroup(string) slog.Handler { return h }
+
+// otelLogSeverity maps a slog.Level onto the otel log API's severity scale, which is deliberately
+// finer-grained than syslog's; see syslogSeverity for why the boundaries below slog.LevelDebug and
+// at/above slog.LevelError+4 matter in practice.
+func otelLogSeverity(level slog.Level) otellog.Severity {
+	switch {
+	case level < slog.LevelDebug:
+		return otellog.SeverityTrace
+	case level < slog.LevelInfo:
+		return otellog.SeverityDebug
+	case level < slog.LevelWarn:
+		return otellog.SeverityInfo
+	case level < slog.LevelError:
+		return otellog.SeverityWarn
+	case level < slog.LevelError+4:
+		return otellog.SeverityError
+	default:
+		return otellog.SeverityFatal
+	}
+}
+
+// logSinksFromEnv parses the WASMCLOUD_LOG_SINKS env var, a comma-separated list of sink URIs
+// (e.g. "syslog://host:514?facility=local0,otlp://collector:4317,file:///var/log/provider.ndjson")
+// into LogSink values, so operators can attach sinks without rebuilding the provider. provider.New
+// applies the result via WithLogSinks ahead of any sinks passed explicitly in code.
+func logSinksFromEnv(value string) ([]LogSink, error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	var sinks []LogSink
+	for _, raw := range strings.Split(value, ",") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			continue
+		}
+		sink, err := parseLogSinkURI(raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WASMCLOUD_LOG_SINKS entry %q: %w", raw, err)
+		}
+		sinks = append(sinks, sink)
+	}
+	return sinks, nil
+}
+
+func parseLogSinkURI(raw string) (LogSink, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "syslog":
+		facility := u.Query().Get("facility")
+		if facility == "" {
+			facility = "user"
+		}
+		network := "udp"
+		if n := u.Query().Get("network"); n != "" {
+			network = n
+		}
+		return SyslogSink(network, u.Host, facility), nil
+	case "file":
+		// A well-formed file:// URI for an absolute path has three slashes ("file:///var/log/x"),
+		// putting the whole path in u.Path with u.Host empty. But "file://x.ndjson" (two slashes,
+		// easy to type by analogy with syslog://host:port) parses with "x.ndjson" in u.Host instead
+		// and an empty u.Path, so join both rather than silently ending up with an empty path.
+		path := u.Host + u.Path
+		rotateBytes, err := parseQueryInt64(u.Query(), "rotate_bytes")
+		if err != nil {
+			return nil, err
+		}
+		rotateKeep, err := parseQueryInt(u.Query(), "rotate_keep")
+		if err != nil {
+			return nil, err
+		}
+		return JSONFileSink(path, rotateBytes, rotateKeep), nil
+	case "otlp", "otlp+http", "otlp+https":
+		headers := map[string]string{}
+		for k, v := range u.Query() {
+			if len(v) > 0 {
+				headers[k] = v[0]
+			}
+		}
+		endpoint := u.Scheme + "://" + u.Host
+		return OTLPLogSink(endpoint, headers), nil
+	default:
+		return nil, fmt.Errorf("unknown log sink scheme %q", u.Scheme)
+	}
+}
+
+func parseQueryInt64(values url.Values, key string) (int64, error) {
+	s := values.Get(key)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(s, 10, 64)
+}
+
+func parseQueryInt(values url.Values, key string) (int, error) {
+	s := values.Get(key)
+	if s == "" {
+		return 0, nil
+	}
+	return strconv.Atoi(s)
+}
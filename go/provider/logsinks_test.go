@@ -0,0 +1,130 @@
+package provider
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+)
+
+// recordingHandler is a slog.Handler that just remembers the records it was asked to handle, for
+// asserting fanoutLogHandler's dispatch behavior.
+type recordingHandler struct {
+	enabled bool
+	records []slog.Record
+	err     error
+}
+
+func (h *recordingHandler) Enabled(context.Context, slog.Level) bool { return h.enabled }
+
+func (h *recordingHandler) Handle(_ context.Context, record slog.Record) error {
+	h.records = append(h.records, record)
+	return h.err
+}
+
+func (h *recordingHandler) WithAttrs([]slog.Attr) slog.Handler { return h }
+func (h *recordingHandler) WithGroup(string) slog.Handler      { return h }
+
+func TestFanoutLogHandlerDispatchesToEnabledHandlersOnly(t *testing.T) {
+	enabled := &recordingHandler{enabled: true}
+	disabled := &recordingHandler{enabled: false}
+	handler := newFanoutLogHandler([]slog.Handler{enabled, disabled})
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	if err := handler.Handle(context.Background(), record); err != nil {
+		t.Fatalf("Handle returned error: %v", err)
+	}
+
+	if len(enabled.records) != 1 {
+		t.Errorf("expected the enabled handler to receive 1 record, got %d", len(enabled.records))
+	}
+	if len(disabled.records) != 0 {
+		t.Errorf("expected the disabled handler to receive no records, got %d", len(disabled.records))
+	}
+}
+
+func TestFanoutLogHandlerJoinsErrorsButKeepsGoing(t *testing.T) {
+	first := &recordingHandler{enabled: true, err: errBoom}
+	second := &recordingHandler{enabled: true}
+	handler := newFanoutLogHandler([]slog.Handler{first, second})
+
+	record := slog.NewRecord(time.Now(), slog.LevelInfo, "hello", 0)
+	err := handler.Handle(context.Background(), record)
+	if err == nil {
+		t.Fatal("expected an error from the failing handler to be returned")
+	}
+	if len(second.records) != 1 {
+		t.Error("expected the second handler to still receive the record despite the first erroring")
+	}
+}
+
+func TestSyslogSeverityMapsExtendedLevels(t *testing.T) {
+	tests := []struct {
+		level slog.Level
+		want  string
+	}{
+		{slog.LevelDebug - 4, "debug"},
+		{slog.LevelDebug, "debug"},
+		{slog.LevelInfo, "info"},
+		{slog.LevelWarn, "warning"},
+		{slog.LevelError, "err"},
+		{slog.LevelError + 4, "crit"},
+	}
+
+	names := map[int]string{7: "debug", 6: "info", 4: "warning", 3: "err", 2: "crit"}
+	for _, tt := range tests {
+		got := syslogSeverity(tt.level)
+		if names[int(got)] != tt.want {
+			t.Errorf("syslogSeverity(%v) = %v, want severity %q", tt.level, got, tt.want)
+		}
+	}
+}
+
+func TestLogSinksFromEnvParsesSyslogFileAndOTLPURIs(t *testing.T) {
+	sinks, err := logSinksFromEnv("syslog://127.0.0.1:1514?facility=local0,file:///tmp/provider.ndjson,otlp://collector:4317")
+	if err != nil {
+		t.Fatalf("logSinksFromEnv returned error: %v", err)
+	}
+	if len(sinks) != 3 {
+		t.Fatalf("expected 3 sinks, got %d", len(sinks))
+	}
+}
+
+func TestLogSinksFromEnvRejectsUnknownScheme(t *testing.T) {
+	if _, err := logSinksFromEnv("carrier-pigeon://example.com"); err == nil {
+		t.Fatal("expected an error for an unknown sink scheme")
+	}
+}
+
+func TestLogSinksFromEnvEmptyIsNoop(t *testing.T) {
+	sinks, err := logSinksFromEnv("")
+	if err != nil {
+		t.Fatalf("logSinksFromEnv returned error: %v", err)
+	}
+	if sinks != nil {
+		t.Errorf("expected no sinks for an empty value, got %v", sinks)
+	}
+}
+
+func TestJSONFileSinkWritesNDJSONAndSharesWriterWithOtelFileExporter(t *testing.T) {
+	dir := t.TempDir()
+	path := dir + "/app.ndjson"
+
+	sink := JSONFileSink(path, 0, 0)
+	handler, shutdown, err := sink()
+	if err != nil {
+		t.Fatalf("JSONFileSink returned error: %v", err)
+	}
+	defer shutdown(context.Background())
+
+	logger := slog.New(handler)
+	logger.Info("hello from JSONFileSink")
+
+	assertNDJSONFileContains(t, path, "hello from JSONFileSink")
+}
+
+var errBoom = &testError{"boom"}
+
+type testError struct{ msg string }
+
+func (e *testError) Error() string { return e.msg }
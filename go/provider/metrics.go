@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// providerMetrics holds the Prometheus collectors the provider runtime populates on behalf of
+// the provider author. It is nil unless WithPrometheusMetrics or WithMetricsRegistry is used.
+type providerMetrics struct {
+	registry *prometheus.Registry
+
+	rpcInvocationsTotal *prometheus.CounterVec
+	rpcDurationSeconds  *prometheus.HistogramVec
+	linkOpsTotal        *prometheus.CounterVec
+	healthcheckStatus   prometheus.Gauge
+	activeLinks         prometheus.Gauge
+	eventsTotal         *prometheus.CounterVec
+}
+
+func newProviderMetrics(registry *prometheus.Registry) *providerMetrics {
+	m := &providerMetrics{
+		registry: registry,
+		rpcInvocationsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wasmcloud_provider_rpc_invocations_total",
+			Help: "Total number of RPC invocations handled or issued by the provider.",
+		}, []string{"operation", "interface", "source", "target", "result"}),
+		rpcDurationSeconds: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "wasmcloud_provider_rpc_duration_seconds",
+			Help:    "Duration of RPC invocations handled or issued by the provider.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"operation", "interface", "source", "target", "result"}),
+		linkOpsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wasmcloud_provider_link_ops_total",
+			Help: "Total number of link put/del operations handled by the provider.",
+		}, []string{"direction", "op", "result"}),
+		healthcheckStatus: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wasmcloud_provider_healthcheck_status",
+			Help: "Result of the most recent health check (1 = healthy, 0 = unhealthy).",
+		}),
+		activeLinks: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "wasmcloud_provider_active_links",
+			Help: "Number of links currently held by the provider, as either source or target.",
+		}),
+		eventsTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "wasmcloud_provider_events_total",
+			Help: "Total number of EventBus deliveries handled by a subscriber, by event kind and result.",
+		}, []string{"kind", "result"}),
+	}
+
+	registry.MustRegister(
+		m.rpcInvocationsTotal,
+		m.rpcDurationSeconds,
+		m.linkOpsTotal,
+		m.healthcheckStatus,
+		m.activeLinks,
+		m.eventsTotal,
+	)
+
+	return m
+}
+
+// WithPrometheusMetrics starts a background HTTP server on addr exposing a Prometheus scrape
+// endpoint at path. Link lifecycle and healthcheck metrics are populated automatically by the
+// provider runtime; RPC metrics only by calls to RecordInboundRPC. Use WithMetricsRegistry instead if the metrics need to be merged into a
+// registry the provider already owns.
+func WithPrometheusMetrics(addr string, path string) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		registry := prometheus.NewRegistry()
+		wp.metrics = newProviderMetrics(registry)
+
+		mux := http.NewServeMux()
+		mux.Handle(path, promhttp.HandlerFor(registry, promhttp.HandlerOpts{}))
+		server := &http.Server{Addr: addr, Handler: mux}
+
+		go func() {
+			if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+				wp.Logger.Error("prometheus metrics server exited", "error", err)
+			}
+		}()
+
+		wp.internalShutdownFuncs = append(wp.internalShutdownFuncs, func(ctx context.Context) error {
+			return server.Shutdown(ctx)
+		})
+
+		return nil
+	}
+}
+
+// WithMetricsRegistry wires provider metrics into an existing Prometheus registry, for callers
+// that already run their own scrape endpoint and want to merge the provider's collectors into it
+// rather than have the runtime start its own HTTP server.
+func WithMetricsRegistry(registry *prometheus.Registry) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.metrics = newProviderMetrics(registry)
+		return nil
+	}
+}
+
+// recordRPCInvocation records a single RPC invocation's outcome and duration. It is a no-op when
+// no metrics option was configured.
+func (wp *WasmcloudProvider) recordRPCInvocation(operation, iface, source, target string, start time.Time, err error) {
+	if wp.metrics == nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	labels := prometheus.Labels{
+		"operation": operation,
+		"interface": iface,
+		"source":    source,
+		"target":    target,
+		"result":    result,
+	}
+	wp.metrics.rpcInvocationsTotal.With(labels).Inc()
+	wp.metrics.rpcDurationSeconds.With(labels).Observe(time.Since(start).Seconds())
+}
+
+// recordLinkOp records a link put/del operation, keyed by whether the provider was acting as the
+// source or target of the link.
+func (wp *WasmcloudProvider) recordLinkOp(direction, op string, err error) {
+	if wp.metrics == nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	wp.metrics.linkOpsTotal.With(prometheus.Labels{"direction": direction, "op": op, "result": result}).Inc()
+	wp.metrics.activeLinks.Set(float64(len(wp.sourceLinks) + len(wp.targetLinks)))
+}
+
+// recordHealthcheckStatus updates the healthcheck gauge to reflect the outcome of the most recent
+// health check response sent to the host.
+func (wp *WasmcloudProvider) recordHealthcheckStatus(healthy bool) {
+	if wp.metrics == nil {
+		return
+	}
+	if healthy {
+		wp.metrics.healthcheckStatus.Set(1)
+	} else {
+		wp.metrics.healthcheckStatus.Set(0)
+	}
+}
+
+// recordEvent records a single EventBus subscriber delivery, keyed by event kind. It is a no-op
+// when no metrics option was configured.
+func (wp *WasmcloudProvider) recordEvent(kind string, err error) {
+	if wp.metrics == nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "error"
+	}
+	wp.metrics.eventsTotal.With(prometheus.Labels{"kind": kind, "result": result}).Inc()
+}
+
+// RecordInboundRPC records one inbound RPC invocation in wasmcloud_provider_rpc_invocations_total
+// and wasmcloud_provider_rpc_duration_seconds. Inbound invocations are served by code generated by
+// wit-bindgen-wrpc (server.Serve in provider main.go), which this SDK does not wrap, so they are
+// recorded only when a handler calls RecordInboundRPC itself. Outgoing calls made through
+// WasmcloudProvider.OutgoingRpcClient are not recorded.
+func (wp *WasmcloudProvider) RecordInboundRPC(operation, iface, source, target string, start time.Time, err error) {
+	wp.recordRPCInvocation(operation, iface, source, target, start, err)
+}
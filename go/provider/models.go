@@ -1,17 +1,23 @@
 package provider
 
 import (
+	"encoding/json"
 	"fmt"
 
 	"github.com/nats-io/nkeys"
 )
 
 type Topics struct {
-	LATTICE_LINK_GET string
-	LATTICE_LINK_DEL string
-	LATTICE_LINK_PUT string
-	LATTICE_SHUTDOWN string
-	LATTICE_HEALTH   string
+	LATTICE_LINK_GET      string
+	LATTICE_LINK_DEL      string
+	LATTICE_LINK_PUT      string
+	LATTICE_SHUTDOWN      string
+	LATTICE_HEALTH        string
+	LATTICE_RPC_AUTH_JWKS string
+	LATTICE_LINK_HEALTH   string
+	LATTICE_CONFIG_UPDATE string
+	LATTICE_EVENTS        string
+	LATTICE_OTEL_CONFIG   string
 }
 
 func LatticeTopics(h HostData, providerXkey nkeys.KeyPair) Topics {
@@ -29,10 +35,33 @@ func LatticeTopics(h HostData, providerXkey nkeys.KeyPair) Topics {
 	}
 
 	return Topics{
-		LATTICE_LINK_GET: fmt.Sprintf("wasmbus.rpc.%s.%s.linkdefs.get", h.LatticeRPCPrefix, h.ProviderKey),
-		LATTICE_LINK_DEL: fmt.Sprintf("wasmbus.rpc.%s.%s.linkdefs.del", h.LatticeRPCPrefix, h.ProviderKey),
-		LATTICE_LINK_PUT: fmt.Sprintf("wasmbus.rpc.%s.%s.linkdefs.put", h.LatticeRPCPrefix, providerLinkPutKey),
-		LATTICE_HEALTH:   fmt.Sprintf("wasmbus.rpc.%s.%s.health", h.LatticeRPCPrefix, h.ProviderKey),
-		LATTICE_SHUTDOWN: fmt.Sprintf("wasmbus.rpc.%s.%s.default.shutdown", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_LINK_GET:      fmt.Sprintf("wasmbus.rpc.%s.%s.linkdefs.get", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_LINK_DEL:      fmt.Sprintf("wasmbus.rpc.%s.%s.linkdefs.del", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_LINK_PUT:      fmt.Sprintf("wasmbus.rpc.%s.%s.linkdefs.put", h.LatticeRPCPrefix, providerLinkPutKey),
+		LATTICE_HEALTH:        fmt.Sprintf("wasmbus.rpc.%s.%s.health", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_SHUTDOWN:      fmt.Sprintf("wasmbus.rpc.%s.%s.default.shutdown", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_RPC_AUTH_JWKS: fmt.Sprintf("wasmbus.rpc.%s.%s.rpcauth.jwks", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_LINK_HEALTH:   fmt.Sprintf("wasmbus.rpc.%s.%s.linkdefs.health", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_CONFIG_UPDATE: fmt.Sprintf("wasmbus.rpc.%s.%s.default.config_put", h.LatticeRPCPrefix, h.ProviderKey),
+		LATTICE_EVENTS:        fmt.Sprintf("wasmbus.evt.%s.>", h.LatticeRPCPrefix),
+		LATTICE_OTEL_CONFIG:   fmt.Sprintf("wasmbus.rpc.%s.%s.otel.config", h.LatticeRPCPrefix, h.ProviderKey),
 	}
 }
+
+// LinkHealth is the structured result of a LinkHealthCheck handler, reported back to wash so it
+// can render per-link health instead of only the provider-wide HealthCheck string.
+type LinkHealth struct {
+	Healthy   bool              `json:"healthy"`
+	LastError string            `json:"last_error,omitempty"`
+	LatencyMS int64             `json:"latency_ms,omitempty"`
+	Values    map[string]string `json:"values,omitempty"`
+}
+
+// ProviderEvent is a single message observed on the lattice's control-plane event stream
+// (wasmbus.evt.<prefix>.>), e.g. component or provider start/stop and health transitions. Data
+// is left undecoded since the event schema varies by Type.
+type ProviderEvent struct {
+	Subject string          `json:"-"`
+	Type    string          `json:"type"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
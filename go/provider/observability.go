@@ -5,26 +5,36 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutmetric"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/log"
 	"go.opentelemetry.io/otel/sdk/metric"
 	"go.opentelemetry.io/otel/sdk/resource"
 	"go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
 const (
 	OtelMetricExportInterval = 1 * time.Minute
 	OtelTraceExportInterval  = 1 * time.Minute
 	OtelLogExportInterval    = 10 * time.Second
+
+	otelCompressionGzip = "gzip"
 )
 
 func newPropagator() propagation.TextMapPropagator {
@@ -34,6 +44,67 @@ func newPropagator() propagation.TextMapPropagator {
 	)
 }
 
+// newOtelSampler parses an OtelConfig.Sampler value into a sdk/trace.Sampler. An empty value
+// defaults to ParentBased(TraceIDRatioBased(1.0)): a sampled parent (from the invoking host or an
+// upstream component) is always honored, while locally originated traces can still be
+// down-sampled by configuring e.g. "parentbased_traceidratio:0.01".
+func newOtelSampler(sampler string) (trace.Sampler, error) {
+	name, ratio, hasRatio := strings.Cut(sampler, ":")
+
+	switch name {
+	case "":
+		return trace.ParentBased(trace.TraceIDRatioBased(1.0)), nil
+	case "always_on":
+		return trace.AlwaysSample(), nil
+	case "always_off":
+		return trace.NeverSample(), nil
+	case "parentbased_always_on":
+		return trace.ParentBased(trace.AlwaysSample()), nil
+	case "parentbased_always_off":
+		return trace.ParentBased(trace.NeverSample()), nil
+	case "traceidratio":
+		r, err := parseOtelSamplerRatio(name, ratio, hasRatio)
+		if err != nil {
+			return nil, err
+		}
+		return trace.TraceIDRatioBased(r), nil
+	case "parentbased_traceidratio":
+		r, err := parseOtelSamplerRatio(name, ratio, hasRatio)
+		if err != nil {
+			return nil, err
+		}
+		return trace.ParentBased(trace.TraceIDRatioBased(r)), nil
+	default:
+		return nil, fmt.Errorf("unknown otel sampler %q", sampler)
+	}
+}
+
+func parseOtelSamplerRatio(name, ratio string, hasRatio bool) (float64, error) {
+	if !hasRatio {
+		return 0, fmt.Errorf("otel sampler %q requires a ratio, e.g. %q", name, name+":0.05")
+	}
+	r, err := strconv.ParseFloat(ratio, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid otel sampler ratio %q: %w", ratio, err)
+	}
+	return r, nil
+}
+
+// otelGRPCDialOptions collects the grpc.DialOption values common to all three gRPC OTLP
+// exporters: TLS credentials (nil means "let the exporter use its own default") and, if
+// config.Proxy is set, a CONNECT-proxy dialer.
+func otelGRPCDialOptions(config OtelConfig) (tlsCreds credentials.TransportCredentials, dialOpts []grpc.DialOption, err error) {
+	tlsCreds, err = newOtelGRPCTLSCredentials(config.TLSClientConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+	dialOpts, err = newOtelGRPCDialOptions(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	return tlsCreds, dialOpts, nil
+}
+
 func newTracerProvider(ctx context.Context, config OtelConfig, serviceResource *resource.Resource) (*trace.TracerProvider, error) {
 	var exporter trace.SpanExporter
 	var err error
@@ -43,13 +114,62 @@ func newTracerProvider(ctx context.Context, config OtelConfig, serviceResource *
 		endpoint = config.ObservabilityEndpoint
 	}
 
-	switch config.Protocol {
-	case OtelProtocolGRPC:
-		exporter, err = otlptracegrpc.New(ctx, otlptracegrpc.WithEndpointURL(endpoint))
-	case OtelProtocolHTTP:
-		exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(endpoint))
-	default:
-		return nil, fmt.Errorf("unknown observability protocol '%s'", config.Protocol)
+	if writer, path, ok, ferr := newOtelFileWriter(config, endpoint); ferr != nil {
+		return nil, ferr
+	} else if ok {
+		var fileExporter *stdouttrace.Exporter
+		fileExporter, err = stdouttrace.New(stdouttrace.WithWriter(writer))
+		if err == nil {
+			exporter = fileClosingSpanExporter{SpanExporter: fileExporter, path: path}
+		}
+	} else {
+		switch config.Protocol {
+		case OtelProtocolGRPC:
+			tlsCreds, dialOpts, derr := otelGRPCDialOptions(config)
+			if derr != nil {
+				return nil, derr
+			}
+			opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpointURL(endpoint)}
+			if len(config.Headers) > 0 {
+				opts = append(opts, otlptracegrpc.WithHeaders(config.Headers))
+			}
+			if tlsCreds != nil {
+				opts = append(opts, otlptracegrpc.WithTLSCredentials(tlsCreds))
+			}
+			if len(dialOpts) > 0 {
+				opts = append(opts, otlptracegrpc.WithDialOption(dialOpts...))
+			}
+			if config.Timeout > 0 {
+				opts = append(opts, otlptracegrpc.WithTimeout(config.Timeout))
+			}
+			if config.Compression == otelCompressionGzip {
+				opts = append(opts, otlptracegrpc.WithCompressor(otelCompressionGzip))
+			}
+			if retry, ok := otlpRetryEnabled(config.Retry); ok {
+				opts = append(opts, otlptracegrpc.WithRetry(otlptracegrpc.RetryConfig(retry)))
+			}
+			exporter, err = otlptracegrpc.New(ctx, opts...)
+		case OtelProtocolHTTP:
+			client, herr := newOtelHTTPClient(config)
+			if herr != nil {
+				return nil, herr
+			}
+			opts := []otlptracehttp.Option{otlptracehttp.WithEndpointURL(endpoint), otlptracehttp.WithHTTPClient(client)}
+			if len(config.Headers) > 0 {
+				opts = append(opts, otlptracehttp.WithHeaders(config.Headers))
+			}
+			if config.Compression == otelCompressionGzip {
+				opts = append(opts, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+			}
+			if retry, ok := otlpRetryEnabled(config.Retry); ok {
+				opts = append(opts, otlptracehttp.WithRetry(otlptracehttp.RetryConfig(retry)))
+			}
+			exporter, err = otlptracehttp.New(ctx, opts...)
+		case OtelProtocolStdout:
+			exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		default:
+			return nil, fmt.Errorf("unknown observability protocol '%s'", config.Protocol)
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -60,13 +180,18 @@ func newTracerProvider(ctx context.Context, config OtelConfig, serviceResource *
 		EventCountLimit:             64,
 	}
 
+	sampler, err := newOtelSampler(config.Sampler)
+	if err != nil {
+		return nil, err
+	}
+
 	traceProvider := trace.NewTracerProvider(
 		trace.WithResource(serviceResource),
 		trace.WithBatcher(exporter,
 			trace.WithBatchTimeout(OtelTraceExportInterval),
 		),
 		trace.WithRawSpanLimits(spanLimits),
-		trace.WithSampler(trace.AlwaysSample()),
+		trace.WithSampler(sampler),
 	)
 
 	return traceProvider, nil
@@ -81,13 +206,62 @@ func newMeterProvider(ctx context.Context, config OtelConfig, serviceResource *r
 		endpoint = config.ObservabilityEndpoint
 	}
 
-	switch config.Protocol {
-	case OtelProtocolGRPC:
-		exporter, err = otlpmetricgrpc.New(ctx, otlpmetricgrpc.WithEndpointURL(endpoint))
-	case OtelProtocolHTTP:
-		exporter, err = otlpmetrichttp.New(ctx, otlpmetrichttp.WithEndpointURL(endpoint))
-	default:
-		return nil, fmt.Errorf("unknown observability protocol '%s'", config.Protocol)
+	if writer, path, ok, ferr := newOtelFileWriter(config, endpoint); ferr != nil {
+		return nil, ferr
+	} else if ok {
+		var fileExporter metric.Exporter
+		fileExporter, err = stdoutmetric.New(stdoutmetric.WithWriter(writer))
+		if err == nil {
+			exporter = fileClosingMetricExporter{Exporter: fileExporter, path: path}
+		}
+	} else {
+		switch config.Protocol {
+		case OtelProtocolGRPC:
+			tlsCreds, dialOpts, derr := otelGRPCDialOptions(config)
+			if derr != nil {
+				return nil, derr
+			}
+			opts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpointURL(endpoint)}
+			if len(config.Headers) > 0 {
+				opts = append(opts, otlpmetricgrpc.WithHeaders(config.Headers))
+			}
+			if tlsCreds != nil {
+				opts = append(opts, otlpmetricgrpc.WithTLSCredentials(tlsCreds))
+			}
+			if len(dialOpts) > 0 {
+				opts = append(opts, otlpmetricgrpc.WithDialOption(dialOpts...))
+			}
+			if config.Timeout > 0 {
+				opts = append(opts, otlpmetricgrpc.WithTimeout(config.Timeout))
+			}
+			if config.Compression == otelCompressionGzip {
+				opts = append(opts, otlpmetricgrpc.WithCompressor(otelCompressionGzip))
+			}
+			if retry, ok := otlpRetryEnabled(config.Retry); ok {
+				opts = append(opts, otlpmetricgrpc.WithRetry(otlpmetricgrpc.RetryConfig(retry)))
+			}
+			exporter, err = otlpmetricgrpc.New(ctx, opts...)
+		case OtelProtocolHTTP:
+			client, herr := newOtelHTTPClient(config)
+			if herr != nil {
+				return nil, herr
+			}
+			opts := []otlpmetrichttp.Option{otlpmetrichttp.WithEndpointURL(endpoint), otlpmetrichttp.WithHTTPClient(client)}
+			if len(config.Headers) > 0 {
+				opts = append(opts, otlpmetrichttp.WithHeaders(config.Headers))
+			}
+			if config.Compression == otelCompressionGzip {
+				opts = append(opts, otlpmetrichttp.WithCompression(otlpmetrichttp.GzipCompression))
+			}
+			if retry, ok := otlpRetryEnabled(config.Retry); ok {
+				opts = append(opts, otlpmetrichttp.WithRetry(otlpmetrichttp.RetryConfig(retry)))
+			}
+			exporter, err = otlpmetrichttp.New(ctx, opts...)
+		case OtelProtocolStdout:
+			exporter, err = stdoutmetric.New(stdoutmetric.WithWriter(os.Stdout))
+		default:
+			return nil, fmt.Errorf("unknown observability protocol '%s'", config.Protocol)
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -111,13 +285,62 @@ func newLoggerProvider(ctx context.Context, config OtelConfig, serviceResource *
 		endpoint = config.ObservabilityEndpoint
 	}
 
-	switch config.Protocol {
-	case OtelProtocolGRPC:
-		exporter, err = otlploggrpc.New(ctx, otlploggrpc.WithEndpointURL(endpoint))
-	case OtelProtocolHTTP:
-		exporter, err = otlploghttp.New(ctx, otlploghttp.WithEndpointURL(endpoint))
-	default:
-		return nil, fmt.Errorf("unknown observability protocol '%s'", config.Protocol)
+	if writer, path, ok, ferr := newOtelFileWriter(config, endpoint); ferr != nil {
+		return nil, ferr
+	} else if ok {
+		var fileExporter log.Exporter
+		fileExporter, err = stdoutlog.New(stdoutlog.WithWriter(writer))
+		if err == nil {
+			exporter = fileClosingLogExporter{Exporter: fileExporter, path: path}
+		}
+	} else {
+		switch config.Protocol {
+		case OtelProtocolGRPC:
+			tlsCreds, dialOpts, derr := otelGRPCDialOptions(config)
+			if derr != nil {
+				return nil, derr
+			}
+			opts := []otlploggrpc.Option{otlploggrpc.WithEndpointURL(endpoint)}
+			if len(config.Headers) > 0 {
+				opts = append(opts, otlploggrpc.WithHeaders(config.Headers))
+			}
+			if tlsCreds != nil {
+				opts = append(opts, otlploggrpc.WithTLSCredentials(tlsCreds))
+			}
+			if len(dialOpts) > 0 {
+				opts = append(opts, otlploggrpc.WithDialOption(dialOpts...))
+			}
+			if config.Timeout > 0 {
+				opts = append(opts, otlploggrpc.WithTimeout(config.Timeout))
+			}
+			if config.Compression == otelCompressionGzip {
+				opts = append(opts, otlploggrpc.WithCompressor(otelCompressionGzip))
+			}
+			if retry, ok := otlpRetryEnabled(config.Retry); ok {
+				opts = append(opts, otlploggrpc.WithRetry(otlploggrpc.RetryConfig(retry)))
+			}
+			exporter, err = otlploggrpc.New(ctx, opts...)
+		case OtelProtocolHTTP:
+			client, herr := newOtelHTTPClient(config)
+			if herr != nil {
+				return nil, herr
+			}
+			opts := []otlploghttp.Option{otlploghttp.WithEndpointURL(endpoint), otlploghttp.WithHTTPClient(client)}
+			if len(config.Headers) > 0 {
+				opts = append(opts, otlploghttp.WithHeaders(config.Headers))
+			}
+			if config.Compression == otelCompressionGzip {
+				opts = append(opts, otlploghttp.WithCompression(otlploghttp.GzipCompression))
+			}
+			if retry, ok := otlpRetryEnabled(config.Retry); ok {
+				opts = append(opts, otlploghttp.WithRetry(otlploghttp.RetryConfig(retry)))
+			}
+			exporter, err = otlploghttp.New(ctx, opts...)
+		case OtelProtocolStdout:
+			exporter, err = stdoutlog.New(stdoutlog.WithWriter(os.Stdout))
+		default:
+			return nil, fmt.Errorf("unknown observability protocol '%s'", config.Protocol)
+		}
 	}
 	if err != nil {
 		return nil, err
@@ -132,17 +355,131 @@ func newLoggerProvider(ctx context.Context, config OtelConfig, serviceResource *
 	return loggerProvider, nil
 }
 
-func newServiceResource(ctx context.Context, name string) (*resource.Resource, error) {
+// fileClosingSpanExporter, fileClosingMetricExporter, and fileClosingLogExporter wrap a stdout*
+// exporter writing to a file-backed rotatingFileWriter so that Shutdown, which the owning
+// trace/meter/logger provider calls exactly once, releases this signal's reference to the
+// (possibly shared, see newOtelFileWriter) writer for path after the underlying exporter has
+// flushed.
+type fileClosingSpanExporter struct {
+	trace.SpanExporter
+	path string
+}
+
+func (e fileClosingSpanExporter) Shutdown(ctx context.Context) error {
+	err := e.SpanExporter.Shutdown(ctx)
+	if cerr := releaseOtelFileWriter(e.path); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+type fileClosingMetricExporter struct {
+	metric.Exporter
+	path string
+}
+
+func (e fileClosingMetricExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if cerr := releaseOtelFileWriter(e.path); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+type fileClosingLogExporter struct {
+	log.Exporter
+	path string
+}
+
+func (e fileClosingLogExporter) Shutdown(ctx context.Context) error {
+	err := e.Exporter.Shutdown(ctx)
+	if cerr := releaseOtelFileWriter(e.path); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// otlpRetry mirrors the {Enabled, InitialInterval, MaxInterval, MaxElapsedTime} shape each
+// otlp*grpc/otlp*http package declares as its own RetryConfig type, letting otlpRetryEnabled build
+// one value and convert it to whichever package-specific type the call site needs.
+type otlpRetry struct {
+	Enabled         bool
+	InitialInterval time.Duration
+	MaxInterval     time.Duration
+	MaxElapsedTime  time.Duration
+}
+
+// otlpRetryEnabled reports whether config's retry settings should be applied, returning a zero
+// value and false when Retry is unset (leaving each exporter's own default retry behavior intact).
+func otlpRetryEnabled(config OtelRetryConfig) (otlpRetry, bool) {
+	if config == (OtelRetryConfig{}) {
+		return otlpRetry{}, false
+	}
+	return otlpRetry{
+		Enabled:         true,
+		InitialInterval: config.InitialInterval,
+		MaxInterval:     config.MaxInterval,
+		MaxElapsedTime:  config.MaxElapsed,
+	}, true
+}
+
+// newServiceResource builds the resource reported alongside every trace, metric, and log: the
+// wasmcloud-specific identifiers dashboards need to correlate telemetry back to a lattice, host,
+// and provider instance, plus the standard process/OS/telemetry-SDK attributes the OTel SDK's own
+// detectors provide. hostData.OtelConfig.ResourceAttributes can append or override any of these.
+func newServiceResource(ctx context.Context, hostData HostData) (*resource.Resource, error) {
 	providerBinary, err := os.Executable()
 	if err != nil {
 		return nil, err
 	}
-	serviceName := semconv.ServiceNameKey.String(filepath.Base(providerBinary))
-	providerName := semconv.ServiceInstanceIDKey.String(name)
+
+	attrs := []attribute.KeyValue{
+		semconv.ServiceNameKey.String(filepath.Base(providerBinary)),
+		semconv.ServiceInstanceIDKey.String(hostData.ProviderKey),
+	}
+	if hostData.LatticeRPCPrefix != "" {
+		attrs = append(attrs, semconv.ServiceNamespaceKey.String(hostData.LatticeRPCPrefix))
+	}
+	if hostData.HostID != "" {
+		attrs = append(attrs, attribute.String("wasmcloud.host_id", hostData.HostID))
+	}
+	if hostData.ProviderKey != "" {
+		attrs = append(attrs, attribute.String("wasmcloud.provider_key", hostData.ProviderKey))
+	}
+	if hostData.LatticeRPCPrefix != "" {
+		attrs = append(attrs, attribute.String("wasmcloud.lattice_rpc_prefix", hostData.LatticeRPCPrefix))
+	}
+	if hostData.InstanceID != "" {
+		attrs = append(attrs, attribute.String("wasmcloud.instance_id", hostData.InstanceID))
+	}
+
+	attrs = append(attrs, parseOtelResourceAttributes(hostData.OtelConfig.ResourceAttributes)...)
+
 	return resource.New(ctx,
-		resource.WithAttributes(
-			serviceName,
-			providerName,
-		),
+		resource.WithProcess(),
+		resource.WithOS(),
+		resource.WithHost(),
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(attrs...),
 	)
 }
+
+// parseOtelResourceAttributes parses the OTEL_RESOURCE_ATTRIBUTES-style "k=v,k=v" syntax used by
+// OtelConfig.ResourceAttributes. Malformed entries (missing "=", or an empty key) are skipped
+// rather than failing resource construction over a typo in user-supplied config.
+func parseOtelResourceAttributes(s string) []attribute.KeyValue {
+	if s == "" {
+		return nil
+	}
+
+	var attrs []attribute.KeyValue
+	for _, pair := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			continue
+		}
+		attrs = append(attrs, attribute.String(key, strings.TrimSpace(value)))
+	}
+	return attrs
+}
@@ -0,0 +1,310 @@
+package provider
+
+import (
+	"compress/gzip"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+func TestNewOtelHTTPTransportAppliesProxy(t *testing.T) {
+	transport, err := newOtelHTTPTransport(OtelConfig{Proxy: "http://proxy.example.com:3128"})
+	if err != nil {
+		t.Fatalf("newOtelHTTPTransport returned error: %v", err)
+	}
+
+	proxyURL, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "collector.example.com"}})
+	if err != nil {
+		t.Fatalf("transport.Proxy returned error: %v", err)
+	}
+	if proxyURL == nil || proxyURL.Host != "proxy.example.com:3128" {
+		t.Errorf("expected configured proxy to be used, got %v", proxyURL)
+	}
+}
+
+func TestNewOtelTLSConfigLoadsCustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	caFile, err := os.CreateTemp(t.TempDir(), "otel-ca-*.pem")
+	if err != nil {
+		t.Fatalf("failed to create temp CA file: %v", err)
+	}
+	if _, err := caFile.Write(caPEM); err != nil {
+		t.Fatalf("failed to write temp CA file: %v", err)
+	}
+	caFile.Close()
+
+	tlsConfig, err := newOtelTLSConfig(OtelTLSConfig{CAFile: caFile.Name()})
+	if err != nil {
+		t.Fatalf("newOtelTLSConfig returned error: %v", err)
+	}
+	if tlsConfig == nil || tlsConfig.RootCAs == nil {
+		t.Fatal("expected a tls.Config with RootCAs populated")
+	}
+
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	resp, err := client.Get(server.URL)
+	if err != nil {
+		t.Fatalf("request against server signed by custom CA failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Errorf("expected 200, got %d", resp.StatusCode)
+	}
+}
+
+func TestNewOtelTLSConfigRejectsUntrustedCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	// An empty pool (no CAFile configured) must not trust the server's self-signed cert.
+	tlsConfig := &tls.Config{RootCAs: x509.NewCertPool()}
+	client := &http.Client{Transport: &http.Transport{TLSClientConfig: tlsConfig}}
+	if _, err := client.Get(server.URL); err == nil {
+		t.Fatal("expected request against untrusted CA to fail")
+	}
+}
+
+func TestNewTracerProviderHTTPDecodesGzipAndRetries(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&requests, 1)
+
+		if r.Header.Get("Content-Encoding") != "gzip" {
+			t.Errorf("expected gzip-encoded request body, got Content-Encoding=%q", r.Header.Get("Content-Encoding"))
+		}
+		gz, err := gzip.NewReader(r.Body)
+		if err != nil {
+			t.Errorf("failed to open gzip reader: %v", err)
+		} else if _, err := io.ReadAll(gz); err != nil {
+			t.Errorf("failed to decode gzip body: %v", err)
+		}
+
+		if n < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	config := OtelConfig{
+		Protocol:    OtelProtocolHTTP,
+		Compression: otelCompressionGzip,
+		Retry: OtelRetryConfig{
+			InitialInterval: 10 * time.Millisecond,
+			MaxInterval:     20 * time.Millisecond,
+			MaxElapsed:      time.Second,
+		},
+	}
+	config.TracesEndpoint = server.URL
+
+	ctx := context.Background()
+	serviceResource, err := resource.New(ctx)
+	if err != nil {
+		t.Fatalf("resource.New returned error: %v", err)
+	}
+
+	traceProvider, err := newTracerProvider(ctx, config, serviceResource)
+	if err != nil {
+		t.Fatalf("newTracerProvider returned error: %v", err)
+	}
+	defer traceProvider.Shutdown(ctx)
+
+	tracer := traceProvider.Tracer("observability-test")
+	_, span := tracer.Start(ctx, "test-span")
+	span.End()
+
+	if err := traceProvider.ForceFlush(ctx); err != nil {
+		t.Fatalf("ForceFlush returned error: %v", err)
+	}
+
+	if got := atomic.LoadInt32(&requests); got < 3 {
+		t.Errorf("expected at least 3 requests (2 failures retried then a success), got %d", got)
+	}
+}
+
+func TestNewOtelSampler(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    sdktrace.Sampler
+		wantErr bool
+	}{
+		{name: "", want: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(1.0))},
+		{name: "always_on", want: sdktrace.AlwaysSample()},
+		{name: "always_off", want: sdktrace.NeverSample()},
+		{name: "parentbased_always_on", want: sdktrace.ParentBased(sdktrace.AlwaysSample())},
+		{name: "traceidratio:0.05", want: sdktrace.TraceIDRatioBased(0.05)},
+		{name: "parentbased_traceidratio:0.01", want: sdktrace.ParentBased(sdktrace.TraceIDRatioBased(0.01))},
+		{name: "traceidratio", wantErr: true},
+		{name: "traceidratio:nope", wantErr: true},
+		{name: "bogus", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newOtelSampler(tt.name)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("expected an error, got nil")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("newOtelSampler returned error: %v", err)
+			}
+			if got.Description() != tt.want.Description() {
+				t.Errorf("got sampler %q, want %q", got.Description(), tt.want.Description())
+			}
+		})
+	}
+}
+
+// TestOtelSamplerParentBasedHonorsSampledParent verifies that, regardless of how aggressively a
+// parentbased_traceidratio sampler down-samples locally originated traces, a span whose parent
+// context is already marked sampled is always sampled too.
+func TestOtelSamplerParentBasedHonorsSampledParent(t *testing.T) {
+	sampler, err := newOtelSampler("parentbased_traceidratio:0.0")
+	if err != nil {
+		t.Fatalf("newOtelSampler returned error: %v", err)
+	}
+
+	traceID, err := randomTraceID()
+	if err != nil {
+		t.Fatalf("failed to generate trace ID: %v", err)
+	}
+
+	sampledParent := oteltrace.NewSpanContext(oteltrace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     oteltrace.SpanID{1},
+		TraceFlags: oteltrace.FlagsSampled,
+	})
+	parentCtx := oteltrace.ContextWithRemoteSpanContext(context.Background(), sampledParent)
+
+	result := sampler.ShouldSample(sdktrace.SamplingParameters{
+		ParentContext: parentCtx,
+		TraceID:       traceID,
+		Name:          "child-span",
+	})
+	if result.Decision != sdktrace.RecordAndSample {
+		t.Errorf("expected a sampled parent to force child sampling despite a 0%% local ratio, got decision %v", result.Decision)
+	}
+}
+
+func randomTraceID() (oteltrace.TraceID, error) {
+	var id oteltrace.TraceID
+	_, err := rand.Read(id[:])
+	return id, err
+}
+
+func TestNewServiceResourceSetsWasmcloudAndProcessAttributes(t *testing.T) {
+	res, err := newServiceResource(context.Background(), HostData{
+		HostID:           "host-public-key",
+		ProviderKey:      "provider-public-key",
+		LatticeRPCPrefix: "default",
+	})
+	if err != nil {
+		t.Fatalf("newServiceResource returned error: %v", err)
+	}
+
+	attrs := resourceAttrs(res)
+	wantStrings := map[attribute.Key]string{
+		"service.instance.id":          "provider-public-key",
+		"service.namespace":            "default",
+		"wasmcloud.host_id":            "host-public-key",
+		"wasmcloud.provider_key":       "provider-public-key",
+		"wasmcloud.lattice_rpc_prefix": "default",
+	}
+	for key, want := range wantStrings {
+		got, ok := attrs[key]
+		if !ok {
+			t.Errorf("expected resource to have attribute %q", key)
+			continue
+		}
+		if got.AsString() != want {
+			t.Errorf("expected %q = %q, got %q", key, want, got.AsString())
+		}
+	}
+
+	// resource.WithProcess() always sets process.pid; presence is enough to confirm the
+	// detector ran without pinning down the actual PID.
+	if _, ok := attrs["process.pid"]; !ok {
+		t.Error("expected resource.WithProcess() to set process.pid")
+	}
+	if _, ok := attrs["os.type"]; !ok {
+		t.Error("expected resource.WithOS() to set os.type")
+	}
+	if _, ok := attrs["telemetry.sdk.language"]; !ok {
+		t.Error("expected resource.WithTelemetrySDK() to set telemetry.sdk.language")
+	}
+}
+
+func TestNewServiceResourceUserAttributesOverrideDefaults(t *testing.T) {
+	hostData := HostData{
+		ProviderKey: "provider-public-key",
+		OtelConfig: OtelConfig{
+			ResourceAttributes: "service.instance.id=overridden,team=platform",
+		},
+	}
+
+	res, err := newServiceResource(context.Background(), hostData)
+	if err != nil {
+		t.Fatalf("newServiceResource returned error: %v", err)
+	}
+
+	attrs := resourceAttrs(res)
+	if got := attrs["service.instance.id"].AsString(); got != "overridden" {
+		t.Errorf("expected a user-supplied resource attribute to override the default, got %q", got)
+	}
+	if got := attrs["team"].AsString(); got != "platform" {
+		t.Errorf("expected user-supplied attribute team=platform, got %q", got)
+	}
+}
+
+func TestParseOtelResourceAttributesSkipsMalformedEntries(t *testing.T) {
+	attrs := parseOtelResourceAttributes("a=1, b=2 , malformed, =3, c=")
+	got := map[string]string{}
+	for _, kv := range attrs {
+		got[string(kv.Key)] = kv.Value.AsString()
+	}
+
+	want := map[string]string{"a": "1", "b": "2", "c": ""}
+	if len(got) != len(want) {
+		t.Fatalf("expected %d parsed attributes, got %d: %v", len(want), len(got), got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Errorf("expected %s=%q, got %q", k, v, got[k])
+		}
+	}
+}
+
+// resourceAttrs indexes res's attributes by key for convenient lookups in assertions.
+func resourceAttrs(res *resource.Resource) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range res.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
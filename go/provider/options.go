@@ -1,45 +1,183 @@
 package provider
 
+import (
+	"context"
+	"fmt"
+
+	"github.com/nats-io/nats.go/jetstream"
+	"github.com/nats-io/nkeys"
+	"go.opentelemetry.io/otel/trace"
+)
+
 type ProviderHandler func(*WasmcloudProvider) error
 
-func SourceLinkPut(inFunc func(InterfaceLinkDefinition) error) ProviderHandler {
+func SourceLinkPut(inFunc func(context.Context, InterfaceLinkDefinition) error) ProviderHandler {
 	return func(wp *WasmcloudProvider) error {
 		wp.putSourceLinkFunc = inFunc
 		return nil
 	}
 }
 
-func TargetLinkPut(inFunc func(InterfaceLinkDefinition) error) ProviderHandler {
+func TargetLinkPut(inFunc func(context.Context, InterfaceLinkDefinition) error) ProviderHandler {
 	return func(wp *WasmcloudProvider) error {
 		wp.putTargetLinkFunc = inFunc
 		return nil
 	}
 }
 
-func SourceLinkDel(inFunc func(InterfaceLinkDefinition) error) ProviderHandler {
+func SourceLinkDel(inFunc func(context.Context, InterfaceLinkDefinition) error) ProviderHandler {
 	return func(wp *WasmcloudProvider) error {
 		wp.delSourceLinkFunc = inFunc
 		return nil
 	}
 }
 
-func TargetLinkDel(inFunc func(InterfaceLinkDefinition) error) ProviderHandler {
+func TargetLinkDel(inFunc func(context.Context, InterfaceLinkDefinition) error) ProviderHandler {
 	return func(wp *WasmcloudProvider) error {
 		wp.delTargetLinkFunc = inFunc
 		return nil
 	}
 }
 
-func Shutdown(inFunc func() error) ProviderHandler {
+func Shutdown(inFunc func(context.Context) error) ProviderHandler {
 	return func(wp *WasmcloudProvider) error {
 		wp.shutdownFunc = inFunc
 		return nil
 	}
 }
 
-func HealthCheck(inFunc func() string) ProviderHandler {
+func HealthCheck(inFunc func(context.Context) string) ProviderHandler {
 	return func(wp *WasmcloudProvider) error {
 		wp.healthMsgFunc = inFunc
 		return nil
 	}
 }
+
+// ConfigUpdate registers inFunc to run whenever the host pushes a new named-config snapshot for
+// this provider (LATTICE_CONFIG_UPDATE), without requiring a link put/del. inFunc receives the
+// full config key/value map, not just the delta.
+func ConfigUpdate(inFunc func(context.Context, map[string]string) error) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.configUpdateFunc = inFunc
+		return nil
+	}
+}
+
+// LinkHealthCheck registers inFunc to answer per-link health queries on LATTICE_LINK_HEALTH,
+// returning a structured LinkHealth instead of the single provider-wide string HealthCheck
+// reports, so wash can render health per link.
+func LinkHealthCheck(inFunc func(context.Context, InterfaceLinkDefinition) LinkHealth) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.linkHealthFunc = inFunc
+		return nil
+	}
+}
+
+// OnReady registers inFunc to run once, after every linkdef present at startup has been applied
+// via the source/target link handlers, but before Start begins serving NATS subscriptions.
+func OnReady(inFunc func(context.Context) error) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.onReadyFunc = inFunc
+		return nil
+	}
+}
+
+// OnEvent registers inFunc as a firehose for every message observed on LATTICE_EVENTS
+// (wasmbus.evt.<lattice-prefix>.>), the lattice's control-plane event stream. Unlike the other
+// handlers, OnEvent doesn't gate anything: delivery failures and decode errors are only logged.
+func OnEvent(inFunc func(context.Context, ProviderEvent)) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.onEventFunc = inFunc
+		return nil
+	}
+}
+
+// WithPolicyEngine enforces engine's policy on LATTICE_LINK_PUT, LATTICE_LINK_DEL, LATTICE_HEALTH,
+// and LATTICE_LINK_HEALTH before the corresponding user-provided handlers run: a denied decision is
+// logged and short-circuits the handler rather than invoking it.
+func WithPolicyEngine(engine *PolicyEngine) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.policyEngine = engine
+		return nil
+	}
+}
+
+// WithSecretsXKey overrides the curve25519 keypair used to decrypt link secrets, which otherwise
+// defaults to the xkey seed advertised by the host in HostData.ProviderXKeyPrivateKey.
+func WithSecretsXKey(seed string) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		xkey, err := nkeys.FromCurveSeed([]byte(seed))
+		if err != nil {
+			return fmt.Errorf("failed to create xkey from seed: %w", err)
+		}
+		wp.providerXkey = xkey
+		setSecretsXKeyContext(xkey, wp.hostData.HostXKeyPublicKey)
+		return nil
+	}
+}
+
+// WithCluster enables cluster, a pre-built Cluster (e.g. wrapping a ClusterKV other than
+// NatsClusterKV), gating putLink/deleteLink per Cluster.ShouldHandleLink. wp.Start calls
+// cluster.Start, and wp.Shutdown calls cluster.Close.
+func WithCluster(cluster *Cluster) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.cluster = cluster
+		return nil
+	}
+}
+
+// WithClustering builds a Cluster coordinating every instance of this provider (same
+// HostData.ProviderKey) connected to the lattice, using a JetStream KV bucket
+// ("wasmcloud_cluster_<providerKey>") as its ClusterKV, and enables it the same way WithCluster
+// does. It's also applied automatically when HostData.ClusterEnabled is set; pass opts here (or
+// call WithCluster with a Cluster built by hand) to customize ClusterMode or the lease TTL.
+func WithClustering(opts ...ClusterOption) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		js, err := jetstream.New(wp.natsConnection)
+		if err != nil {
+			return fmt.Errorf("failed to create jetstream context for clustering: %w", err)
+		}
+
+		kv, err := js.CreateOrUpdateKeyValue(context.Background(), jetstream.KeyValueConfig{
+			Bucket: fmt.Sprintf("wasmcloud_cluster_%s", wp.hostData.ProviderKey),
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create cluster kv bucket: %w", err)
+		}
+
+		wp.cluster = NewCluster(NewNatsClusterKV(kv), wp.hostData.ProviderKey, wp.hostData.InstanceID, opts...)
+		return nil
+	}
+}
+
+// WithTransport overrides the Transport New otherwise selects from HostData.RPCTransport (an
+// unset RPCTransport defaults to NatsTransport). Call it to supply a Transport configured in Go
+// rather than through HostData, e.g. a GrpcTransport with a non-default Addr.
+func WithTransport(transport Transport) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.transport = transport
+		return nil
+	}
+}
+
+// WithEventMiddleware registers mw on the provider's EventBus (see WasmcloudProvider.Events),
+// wrapping every subscriber registered after this option runs. provider.New registers the default
+// LinkPutEvent/LinkDelEvent subscribers before running options, so mw only wraps subscribers a
+// provider author adds afterwards via Events().Subscribe, not those defaults.
+func WithEventMiddleware(mw ...EventMiddleware) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.events.Use(mw...)
+		return nil
+	}
+}
+
+// WithTracing overrides the TracerProvider WasmcloudProvider's tracer is built from, which
+// otherwise defaults to otel.GetTracerProvider(). It's most useful paired with SetupOtel's own
+// TracerProvider, so StartRPCSpan's spans export through the same pipeline as the rest of the
+// provider's telemetry.
+func WithTracing(tp trace.TracerProvider) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.tracer = tp.Tracer(fmt.Sprintf("wasmcloud-provider-%s", wp.hostData.ProviderKey))
+		return nil
+	}
+}
@@ -0,0 +1,231 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/log"
+	"go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// otelReconfigureShutdownTimeout bounds how long Reconfigure waits for the outgoing
+// TracerProvider/MeterProvider/LoggerProvider to flush their in-flight batches before moving on
+// to installing the new ones.
+const otelReconfigureShutdownTimeout = 10 * time.Second
+
+// OtelManager owns the live TracerProvider, MeterProvider, and LoggerProvider built from an
+// OtelConfig, and lets them be swapped out at runtime via Reconfigure without restarting the
+// provider process. SetupOtel constructs one at startup; provider.New wires its Shutdown method
+// into WasmcloudProvider's shutdown sequence.
+type OtelManager struct {
+	mu sync.Mutex
+
+	hostData        HostData
+	serviceResource *resource.Resource
+
+	config         OtelConfig
+	tracerProvider *sdktrace.TracerProvider
+	meterProvider  *metric.MeterProvider
+	loggerProvider *log.LoggerProvider
+}
+
+// SetupOtel builds the TracerProvider, MeterProvider, and LoggerProvider described by
+// hostData.OtelConfig, installs them as the global OpenTelemetry providers, and returns the
+// OtelManager that owns them. provider.New calls this once at startup and registers the returned
+// manager's Shutdown method as part of WasmcloudProvider.Shutdown.
+func SetupOtel(ctx context.Context, hostData HostData) (*OtelManager, error) {
+	otel.SetTextMapPropagator(newPropagator())
+
+	serviceResource, err := newServiceResource(ctx, hostData)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &OtelManager{hostData: hostData, serviceResource: serviceResource}
+	if err := m.install(ctx, hostData.OtelConfig); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// install builds and globally registers the providers enabled by config, without touching
+// whatever is already set on m. Callers decide what to do with the previous providers (New simply
+// discards the zero value; Reconfigure shuts the old ones down once the new ones are up).
+func (m *OtelManager) install(ctx context.Context, config OtelConfig) error {
+	var meterProvider *metric.MeterProvider
+	if config.EnableObservability || (config.EnableMetrics != nil && *config.EnableMetrics) {
+		var err error
+		meterProvider, err = newMeterProvider(ctx, config, m.serviceResource)
+		if err != nil {
+			return err
+		}
+	}
+
+	var tracerProvider *sdktrace.TracerProvider
+	if config.EnableObservability || (config.EnableTraces != nil && *config.EnableTraces) {
+		var err error
+		tracerProvider, err = newTracerProvider(ctx, config, m.serviceResource)
+		if err != nil {
+			return err
+		}
+	}
+
+	var loggerProvider *log.LoggerProvider
+	if config.EnableObservability || (config.EnableLogs != nil && *config.EnableLogs) {
+		var err error
+		loggerProvider, err = newLoggerProvider(ctx, config, m.serviceResource)
+		if err != nil {
+			return err
+		}
+	}
+
+	if meterProvider != nil {
+		otel.SetMeterProvider(meterProvider)
+	}
+	if tracerProvider != nil {
+		otel.SetTracerProvider(tracerProvider)
+	}
+	if loggerProvider != nil {
+		global.SetLoggerProvider(loggerProvider)
+	}
+
+	m.config = config
+	m.tracerProvider = tracerProvider
+	m.meterProvider = meterProvider
+	m.loggerProvider = loggerProvider
+	return nil
+}
+
+// Reconfigure rebuilds the observability pipeline from update layered onto the current OtelConfig
+// and, only once the replacement providers have been built successfully, gracefully shuts down
+// (with a bounded deadline, flushing in-flight batches) and discards the old ones. If building the
+// new providers fails, the prior providers are left running untouched and the error is returned.
+func (m *OtelManager) Reconfigure(ctx context.Context, update OtelConfigUpdate) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	newConfig := m.config
+	if update.Protocol != "" {
+		newConfig.Protocol = update.Protocol
+	}
+	if update.Endpoint != "" {
+		newConfig.ObservabilityEndpoint = update.Endpoint
+	}
+	if update.TracesEndpoint != "" {
+		newConfig.TracesEndpoint = update.TracesEndpoint
+	}
+	if update.MetricsEndpoint != "" {
+		newConfig.MetricsEndpoint = update.MetricsEndpoint
+	}
+	if update.LogsEndpoint != "" {
+		newConfig.LogsEndpoint = update.LogsEndpoint
+	}
+	if update.Sampler != "" {
+		newConfig.Sampler = update.Sampler
+	}
+	if update.Headers != nil {
+		newConfig.Headers = update.Headers
+	}
+
+	oldTracerProvider, oldMeterProvider, oldLoggerProvider := m.tracerProvider, m.meterProvider, m.loggerProvider
+
+	if err := m.install(ctx, newConfig); err != nil {
+		return fmt.Errorf("failed to rebuild otel providers: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(ctx, otelReconfigureShutdownTimeout)
+	defer cancel()
+
+	var shutdownErrs []error
+	if oldTracerProvider != nil {
+		if err := oldTracerProvider.Shutdown(shutdownCtx); err != nil {
+			shutdownErrs = append(shutdownErrs, err)
+		}
+	}
+	if oldMeterProvider != nil {
+		if err := oldMeterProvider.Shutdown(shutdownCtx); err != nil {
+			shutdownErrs = append(shutdownErrs, err)
+		}
+	}
+	if oldLoggerProvider != nil {
+		if err := oldLoggerProvider.Shutdown(shutdownCtx); err != nil {
+			shutdownErrs = append(shutdownErrs, err)
+		}
+	}
+	if len(shutdownErrs) > 0 {
+		return fmt.Errorf("reconfigured otel providers, but failed to cleanly shut down the previous ones: %v", shutdownErrs)
+	}
+	return nil
+}
+
+// Shutdown flushes and closes whichever providers are currently installed.
+func (m *OtelManager) Shutdown(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var errs []error
+	if m.meterProvider != nil {
+		if err := m.meterProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if m.tracerProvider != nil {
+		if err := m.tracerProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if m.loggerProvider != nil {
+		if err := m.loggerProvider.Shutdown(ctx); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("failed to shut down otel providers: %v", errs)
+	}
+	return nil
+}
+
+// natsHeaderCarrier adapts nats.Header (a map[string][]string, same shape as http.Header) to
+// OpenTelemetry's propagation.TextMapCarrier so W3C tracecontext can be extracted from, or
+// injected into, a NATS message's headers.
+type natsHeaderCarrier nats.Header
+
+func (c natsHeaderCarrier) Get(key string) string {
+	values := nats.Header(c)[key]
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (c natsHeaderCarrier) Set(key, value string) {
+	nats.Header(c)[key] = []string{value}
+}
+
+func (c natsHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// startSpanFromNatsMsg extracts a W3C tracecontext from msg's headers (if present) and starts a
+// span named spanName as its continuation, so lattice control-plane messages on wasmbus.rpc.*
+// subjects (health checks, link put/del, shutdown) show up as spans alongside RPC traffic.
+func (wp *WasmcloudProvider) startSpanFromNatsMsg(msg *nats.Msg, spanName string) (context.Context, trace.Span) {
+	ctx := context.Background()
+	if msg.Header != nil {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, natsHeaderCarrier(msg.Header))
+	}
+	return wp.tracer.Start(ctx, spanName)
+}
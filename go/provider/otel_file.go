@@ -0,0 +1,202 @@
+package provider
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// newOtelFileWriter returns the path-deduplicated *rotatingFileWriter a stdout* exporter should
+// write NDJSON to for a given signal, and whether config/endpoint selected the file backend.
+// Either a "file://" endpoint, or config.Protocol set to OtelProtocolFile (in which case endpoint
+// is the path itself, with or without a "file://" prefix), selects it; this is checked
+// per-signal, so e.g. metrics can go out over OTLP gRPC while traces are dumped to a local file
+// for debugging. Anything else returns ok=false and leaves exporter selection to config.Protocol.
+//
+// Multiple signals resolving to the same path (e.g. all sharing ObservabilityEndpoint) share a
+// single underlying writer, so rotation stays consistent instead of racing independent writers
+// against the same file.
+func newOtelFileWriter(config OtelConfig, endpoint string) (writer *rotatingFileWriter, path string, ok bool, err error) {
+	path, hasScheme := strings.CutPrefix(endpoint, "file://")
+	if !hasScheme {
+		if config.Protocol != OtelProtocolFile || endpoint == "" {
+			return nil, "", false, nil
+		}
+		path = endpoint
+	}
+
+	w, err := acquireOtelFileWriter(path, config.MaxSizeBytes, config.MaxAgeSeconds, config.MaxBackups)
+	if err != nil {
+		return nil, "", false, err
+	}
+	return w, path, true, nil
+}
+
+// otelFileWriters dedupes *rotatingFileWriter instances by path so concurrently or repeatedly
+// installed providers that resolve to the same file share one writer (and one set of rotation
+// decisions) instead of fighting over the same path, and refcounts them so the file is only
+// closed once every signal (and every Reconfigure generation) using it has released it.
+var otelFileWriters = struct {
+	mu      sync.Mutex
+	entries map[string]*otelFileWriterEntry
+}{entries: make(map[string]*otelFileWriterEntry)}
+
+type otelFileWriterEntry struct {
+	writer *rotatingFileWriter
+	refs   int
+}
+
+func acquireOtelFileWriter(path string, maxSizeBytes, maxAgeSeconds int64, maxBackups int) (*rotatingFileWriter, error) {
+	otelFileWriters.mu.Lock()
+	defer otelFileWriters.mu.Unlock()
+
+	if entry, ok := otelFileWriters.entries[path]; ok {
+		entry.refs++
+		return entry.writer, nil
+	}
+
+	w, err := newRotatingFileWriter(path, maxSizeBytes, maxAgeSeconds, maxBackups)
+	if err != nil {
+		return nil, err
+	}
+	otelFileWriters.entries[path] = &otelFileWriterEntry{writer: w, refs: 1}
+	return w, nil
+}
+
+// releaseOtelFileWriter drops a reference to the writer for path, closing and forgetting it once
+// nothing else holds one. Called from the fileClosing* exporter wrappers' Shutdown methods, which
+// the owning trace/meter/logger provider invokes exactly once.
+func releaseOtelFileWriter(path string) error {
+	otelFileWriters.mu.Lock()
+	defer otelFileWriters.mu.Unlock()
+
+	entry, ok := otelFileWriters.entries[path]
+	if !ok {
+		return nil
+	}
+	entry.refs--
+	if entry.refs > 0 {
+		return nil
+	}
+	delete(otelFileWriters.entries, path)
+	return entry.writer.file.Close()
+}
+
+// rotatingFileWriter is an io.Writer over a single NDJSON file that rotates to a timestamped
+// backup once it exceeds maxSizeBytes or has been open longer than maxAge, keeping at most
+// maxBackups of the renamed files. A zero maxSizeBytes or maxAge disables that rotation trigger.
+type rotatingFileWriter struct {
+	mu sync.Mutex
+
+	path         string
+	maxSizeBytes int64
+	maxAge       time.Duration
+	maxBackups   int
+
+	file     *os.File
+	size     int64
+	openedAt time.Time
+}
+
+func newRotatingFileWriter(path string, maxSizeBytes, maxAgeSeconds int64, maxBackups int) (*rotatingFileWriter, error) {
+	w := &rotatingFileWriter{
+		path:         path,
+		maxSizeBytes: maxSizeBytes,
+		maxAge:       time.Duration(maxAgeSeconds) * time.Second,
+		maxBackups:   maxBackups,
+	}
+	if err := w.open(); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+func (w *rotatingFileWriter) open() error {
+	if dir := filepath.Dir(w.path); dir != "." {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("failed to create otel file exporter directory %q: %w", dir, err)
+		}
+	}
+
+	f, err := os.OpenFile(w.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open otel file exporter output %q: %w", w.path, err)
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return fmt.Errorf("failed to stat otel file exporter output %q: %w", w.path, err)
+	}
+
+	w.file = f
+	w.size = info.Size()
+	w.openedAt = time.Now()
+	return nil
+}
+
+func (w *rotatingFileWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.shouldRotate(int64(len(p))) {
+		if err := w.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := w.file.Write(p)
+	w.size += int64(n)
+	return n, err
+}
+
+func (w *rotatingFileWriter) shouldRotate(nextWrite int64) bool {
+	if w.maxSizeBytes > 0 && w.size+nextWrite > w.maxSizeBytes {
+		return true
+	}
+	if w.maxAge > 0 && time.Since(w.openedAt) > w.maxAge {
+		return true
+	}
+	return false
+}
+
+func (w *rotatingFileWriter) rotate() error {
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("failed to close otel file exporter output %q before rotating: %w", w.path, err)
+	}
+
+	backup := w.path + "." + time.Now().UTC().Format("20060102T150405.000000000Z")
+	if err := os.Rename(w.path, backup); err != nil {
+		return fmt.Errorf("failed to rotate otel file exporter output %q: %w", w.path, err)
+	}
+
+	if err := w.open(); err != nil {
+		return err
+	}
+	return w.pruneBackups()
+}
+
+func (w *rotatingFileWriter) pruneBackups() error {
+	if w.maxBackups <= 0 {
+		return nil
+	}
+
+	matches, err := filepath.Glob(w.path + ".*")
+	if err != nil {
+		return fmt.Errorf("failed to list rotated otel file exporter backups for %q: %w", w.path, err)
+	}
+	if len(matches) <= w.maxBackups {
+		return nil
+	}
+
+	sort.Strings(matches) // the timestamp suffix sorts lexically in chronological order
+	for _, stale := range matches[:len(matches)-w.maxBackups] {
+		if err := os.Remove(stale); err != nil {
+			return fmt.Errorf("failed to prune rotated otel file exporter backup %q: %w", stale, err)
+		}
+	}
+	return nil
+}
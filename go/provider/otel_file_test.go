@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewOtelFileWriterRequiresFileSchemeOrProtocol(t *testing.T) {
+	writer, path, ok, err := newOtelFileWriter(OtelConfig{}, "http://127.0.0.1:4318")
+	if err != nil {
+		t.Fatalf("newOtelFileWriter returned error: %v", err)
+	}
+	if ok || writer != nil || path != "" {
+		t.Errorf("expected a non-file:// endpoint under an OTLP protocol to be left to config.Protocol, got ok=%v writer=%v path=%q", ok, writer, path)
+	}
+}
+
+func TestNewOtelFileWriterProtocolFileUsesEndpointAsPath(t *testing.T) {
+	wantPath := filepath.Join(t.TempDir(), "traces.ndjson")
+	defer releaseOtelFileWriter(wantPath)
+
+	writer, path, ok, err := newOtelFileWriter(OtelConfig{Protocol: OtelProtocolFile}, wantPath)
+	if err != nil {
+		t.Fatalf("newOtelFileWriter returned error: %v", err)
+	}
+	if !ok || writer == nil {
+		t.Fatal("expected Protocol: OtelProtocolFile to select the file backend")
+	}
+	if path != wantPath {
+		t.Errorf("expected path %q, got %q", wantPath, path)
+	}
+}
+
+func TestNewTracerProviderFileEndpointWritesNDJSON(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "traces.ndjson")
+
+	serviceResource, err := newServiceResource(ctx, HostData{ProviderKey: "test-provider"})
+	if err != nil {
+		t.Fatalf("newServiceResource returned error: %v", err)
+	}
+
+	tracerProvider, err := newTracerProvider(ctx, OtelConfig{TracesEndpoint: "file://" + path}, serviceResource)
+	if err != nil {
+		t.Fatalf("newTracerProvider returned error: %v", err)
+	}
+
+	tracer := tracerProvider.Tracer("otel-file-test")
+	_, span := tracer.Start(ctx, "test-span")
+	span.End()
+
+	if err := tracerProvider.Shutdown(ctx); err != nil {
+		t.Fatalf("tracerProvider.Shutdown returned error: %v", err)
+	}
+
+	assertNDJSONFileContains(t, path, "test-span")
+}
+
+func TestRotatingFileWriterRotatesOnSize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otel.ndjson")
+
+	w, err := newRotatingFileWriter(path, 16, 0, 0)
+	if err != nil {
+		t.Fatalf("newRotatingFileWriter returned error: %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		if _, err := w.Write([]byte(`{"line":1}` + "\n")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("filepath.Glob returned error: %v", err)
+	}
+	if len(matches) == 0 {
+		t.Error("expected at least one rotated backup file after exceeding MaxSizeBytes")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected the active file to still exist after rotation: %v", err)
+	}
+}
+
+func TestRotatingFileWriterPrunesBackups(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otel.ndjson")
+
+	w, err := newRotatingFileWriter(path, 8, 0, 2)
+	if err != nil {
+		t.Fatalf("newRotatingFileWriter returned error: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		if _, err := w.Write([]byte(`{"line":1}` + "\n")); err != nil {
+			t.Fatalf("Write returned error: %v", err)
+		}
+	}
+
+	matches, err := filepath.Glob(path + ".*")
+	if err != nil {
+		t.Fatalf("filepath.Glob returned error: %v", err)
+	}
+	if len(matches) > 2 {
+		t.Errorf("expected MaxBackups to cap rotated files at 2, got %d: %v", len(matches), matches)
+	}
+}
+
+func TestAcquireOtelFileWriterSharesAndRefcountsByPath(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "otel.ndjson")
+
+	first, err := acquireOtelFileWriter(path, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("acquireOtelFileWriter returned error: %v", err)
+	}
+	second, err := acquireOtelFileWriter(path, 0, 0, 0)
+	if err != nil {
+		t.Fatalf("acquireOtelFileWriter returned error: %v", err)
+	}
+	if first != second {
+		t.Fatal("expected two acquires of the same path to share one rotatingFileWriter")
+	}
+
+	if err := releaseOtelFileWriter(path); err != nil {
+		t.Fatalf("releaseOtelFileWriter returned error: %v", err)
+	}
+	if _, err := first.file.Write([]byte("still open\n")); err != nil {
+		t.Errorf("expected the writer to remain open after only one of two releases, got: %v", err)
+	}
+
+	if err := releaseOtelFileWriter(path); err != nil {
+		t.Fatalf("releaseOtelFileWriter returned error: %v", err)
+	}
+	if _, err := first.file.Write([]byte("should fail\n")); err == nil {
+		t.Error("expected the writer's file to be closed once every reference is released")
+	}
+}
+
+// assertNDJSONFileContains parses path as newline-delimited JSON and fails the test unless one
+// line, rendered back to a string, contains want.
+func assertNDJSONFileContains(t *testing.T, path, want string) {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %q: %v", path, err)
+	}
+
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if line == "" {
+			continue
+		}
+		var decoded map[string]any
+		if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+			t.Fatalf("failed to parse NDJSON line %q: %v", line, err)
+		}
+		if strings.Contains(line, want) {
+			return
+		}
+	}
+	t.Errorf("expected a line in %q to contain %q, got:\n%s", path, want, data)
+}
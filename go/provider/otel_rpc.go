@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"context"
+	"sync"
+
+	wrpcnats "github.com/bytecodealliance/wrpc/go/nats"
+	"github.com/nats-io/nats.go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StartRPCSpan extracts a W3C tracecontext from the NATS header wrpcnats attaches to ctx for an
+// incoming RPC call (see wrpcnats.HeaderFromContext), so the span it starts is a child of the
+// caller's span rather than a root, and names it "<witInterface>.<function>" with a source-id
+// attribute identifying the calling component. When a target link from that source is known, its
+// link-name is attached too. Providers call this as the first line of every wit-generated handler
+// method, in place of calling p.tracer.Start directly.
+//
+// The returned span also marks the call in-flight on wp.inflightRPCs until its End method runs,
+// so Shutdown's ShutdownPhaseDrainRPC phase can wait for handler methods already running to
+// finish before the rest of shutdown proceeds.
+func (wp *WasmcloudProvider) StartRPCSpan(ctx context.Context, witInterface, function string) (context.Context, trace.Span) {
+	var sourceID string
+	if header, ok := wrpcnats.HeaderFromContext(ctx); ok {
+		ctx = otel.GetTextMapPropagator().Extract(ctx, natsHeaderCarrier(header))
+		sourceID = header.Get("source-id")
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("rpc.system", "wasmcloud"),
+		attribute.String("rpc.service", witInterface),
+		attribute.String("rpc.method", function),
+	}
+	if sourceID != "" {
+		attrs = append(attrs, attribute.String("source-id", sourceID))
+
+		wp.lock.Lock()
+		link, linked := wp.targetLinks[sourceID]
+		wp.lock.Unlock()
+		if linked && link.Name != "" {
+			attrs = append(attrs, attribute.String("link-name", link.Name))
+		}
+	}
+
+	ctx, span := wp.tracer.Start(ctx, witInterface+"."+function, trace.WithAttributes(attrs...))
+
+	wp.inflightRPCs.Add(1)
+	var once sync.Once
+	return ctx, &inflightRPCSpan{Span: span, done: func() { once.Do(wp.inflightRPCs.Done) }}
+}
+
+// inflightRPCSpan wraps the trace.Span StartRPCSpan starts so that whichever of its End or
+// RecordError-then-End call pattern a handler uses, the call is marked done on wp.inflightRPCs
+// exactly once, when End finally runs.
+type inflightRPCSpan struct {
+	trace.Span
+	done func()
+}
+
+func (s *inflightRPCSpan) End(options ...trace.SpanEndOption) {
+	s.done()
+	s.Span.End(options...)
+}
+
+// InjectRPCContext injects the span in ctx (if any) into the NATS header wrpcnats attaches to the
+// next outgoing call made with the returned context via a *wrpcnats.Client (see
+// WasmcloudProvider.OutgoingRpcClient), so the callee's StartRPCSpan continues this trace instead
+// of starting a new one. Call it on the context passed to a generated binding's client function.
+func InjectRPCContext(ctx context.Context) context.Context {
+	header, ok := wrpcnats.HeaderFromContext(ctx)
+	if !ok {
+		header = nats.Header{}
+	}
+	otel.GetTextMapPropagator().Inject(ctx, natsHeaderCarrier(header))
+	return wrpcnats.ContextWithHeader(ctx, header)
+}
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+)
+
+func TestOtelManagerReconfigureReplacesTracerProvider(t *testing.T) {
+	ctx := context.Background()
+
+	serviceResource, err := newServiceResource(ctx, HostData{ProviderKey: "test-provider"})
+	if err != nil {
+		t.Fatalf("newServiceResource returned error: %v", err)
+	}
+
+	m := &OtelManager{hostData: HostData{ProviderKey: "test-provider"}, serviceResource: serviceResource}
+	if err := m.install(ctx, OtelConfig{EnableTraces: boolPtr(true), Protocol: OtelProtocolHTTP, TracesEndpoint: "http://127.0.0.1:4318"}); err != nil {
+		t.Fatalf("install returned error: %v", err)
+	}
+
+	originalTracerProvider := m.tracerProvider
+
+	err = m.Reconfigure(ctx, OtelConfigUpdate{TracesEndpoint: "http://127.0.0.1:4319", Sampler: "always_off"})
+	if err != nil {
+		t.Fatalf("Reconfigure returned error: %v", err)
+	}
+
+	if m.tracerProvider == originalTracerProvider {
+		t.Error("expected Reconfigure to install a new TracerProvider")
+	}
+	if m.config.TracesEndpoint != "http://127.0.0.1:4319" {
+		t.Errorf("expected TracesEndpoint to be updated, got %q", m.config.TracesEndpoint)
+	}
+	if m.config.Sampler != "always_off" {
+		t.Errorf("expected Sampler to be updated, got %q", m.config.Sampler)
+	}
+	if otel.GetTracerProvider() != m.tracerProvider {
+		t.Error("expected the new TracerProvider to be installed as the global otel TracerProvider")
+	}
+}
+
+func TestOtelManagerReconfigureFailureLeavesPriorProvidersIntact(t *testing.T) {
+	ctx := context.Background()
+
+	serviceResource, err := newServiceResource(ctx, HostData{ProviderKey: "test-provider"})
+	if err != nil {
+		t.Fatalf("newServiceResource returned error: %v", err)
+	}
+
+	m := &OtelManager{hostData: HostData{ProviderKey: "test-provider"}, serviceResource: serviceResource}
+	if err := m.install(ctx, OtelConfig{EnableTraces: boolPtr(true), Protocol: OtelProtocolHTTP, TracesEndpoint: "http://127.0.0.1:4318"}); err != nil {
+		t.Fatalf("install returned error: %v", err)
+	}
+
+	originalTracerProvider := m.tracerProvider
+	originalProtocol := m.config.Protocol
+	originalTracesEndpoint := m.config.TracesEndpoint
+
+	// An unknown protocol makes newTracerProvider fail, so Reconfigure must leave the existing
+	// provider and config untouched rather than partially applying the update.
+	err = m.Reconfigure(ctx, OtelConfigUpdate{Protocol: "bogus-protocol"})
+	if err == nil {
+		t.Fatal("expected Reconfigure to return an error for an unknown protocol")
+	}
+
+	if m.tracerProvider != originalTracerProvider {
+		t.Error("expected the prior TracerProvider to remain installed after a failed Reconfigure")
+	}
+	if m.config.Protocol != originalProtocol || m.config.TracesEndpoint != originalTracesEndpoint {
+		t.Errorf("expected config to be unchanged after a failed Reconfigure, got %+v", m.config)
+	}
+}
+
+func boolPtr(b bool) *bool { return &b }
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"bufio"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// newOtelTLSConfig builds a *tls.Config from an OtelTLSConfig, loading the client certificate and
+// custom CA from disk. A zero-value OtelTLSConfig yields a nil *tls.Config, which tells the OTLP
+// exporters and gRPC dial options to fall back to their own defaults (no client cert, system CA
+// pool, normal hostname verification).
+func newOtelTLSConfig(config OtelTLSConfig) (*tls.Config, error) {
+	if config == (OtelTLSConfig{}) {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}
+
+	if config.CertFile != "" || config.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load otel client cert/key: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	if config.CAFile != "" {
+		caPEM, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read otel CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(caPEM) {
+			return nil, fmt.Errorf("failed to parse otel CA file %q", config.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	return tlsConfig, nil
+}
+
+// newOtelHTTPTransport builds the *http.Transport shared by all three HTTP OTLP exporters
+// (traces/metrics/logs), applying config's TLS and proxy settings in one place so the three
+// constructors don't each reimplement it. An empty Proxy falls back to the standard
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment variables, matching net/http's default transport.
+func newOtelHTTPTransport(config OtelConfig) (*http.Transport, error) {
+	tlsConfig, err := newOtelTLSConfig(config.TLSClientConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	proxy := http.ProxyFromEnvironment
+	if config.Proxy != "" {
+		proxyURL, err := url.Parse(config.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse otel proxy url: %w", err)
+		}
+		proxy = http.ProxyURL(proxyURL)
+	}
+
+	return &http.Transport{
+		TLSClientConfig: tlsConfig,
+		Proxy:           proxy,
+	}, nil
+}
+
+// newOtelHTTPClient wraps newOtelHTTPTransport in an *http.Client with config.Timeout applied, for
+// exporters like otlptracehttp that accept a full client via WithHTTPClient rather than a bare
+// transport.
+func newOtelHTTPClient(config OtelConfig) (*http.Client, error) {
+	transport, err := newOtelHTTPTransport(config)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Client{Transport: transport, Timeout: config.Timeout}, nil
+}
+
+// newOtelGRPCTLSCredentials returns TLS transport credentials for the gRPC OTLP exporters, or nil
+// if config has no TLS settings, in which case callers pass no credentials option and the
+// exporter's own default applies.
+func newOtelGRPCTLSCredentials(config OtelTLSConfig) (credentials.TransportCredentials, error) {
+	tlsConfig, err := newOtelTLSConfig(config)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfig == nil {
+		return nil, nil
+	}
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// newOtelGRPCDialOptions returns the extra grpc.DialOption values needed to route the gRPC OTLP
+// exporters through config.Proxy. It's empty when Proxy is unset, leaving gRPC's own
+// HTTP_PROXY/HTTPS_PROXY environment handling in place.
+func newOtelGRPCDialOptions(config OtelConfig) ([]grpc.DialOption, error) {
+	if config.Proxy == "" {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(config.Proxy)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse otel proxy url: %w", err)
+	}
+
+	return []grpc.DialOption{grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return dialViaHTTPConnectProxy(ctx, proxyURL, addr)
+	})}, nil
+}
+
+// dialViaHTTPConnectProxy opens a TCP connection to proxyURL and issues an HTTP CONNECT tunnel to
+// addr, returning the tunneled connection once the proxy answers 200. This is the same tunneling
+// net/http's Transport does internally for HTTPS-over-proxy; gRPC's transport doesn't expose it,
+// so it's reimplemented here for WithContextDialer.
+func dialViaHTTPConnectProxy(ctx context.Context, proxyURL *url.URL, addr string) (net.Conn, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "tcp", proxyURL.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial otel proxy: %w", err)
+	}
+
+	connectReq := &http.Request{
+		Method: http.MethodConnect,
+		URL:    &url.URL{Opaque: addr},
+		Host:   addr,
+		Header: make(http.Header),
+	}
+	if proxyURL.User != nil {
+		connectReq.Header.Set("Proxy-Authorization", "Basic "+basicAuth(proxyURL.User))
+	}
+	if err := connectReq.Write(conn); err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to write CONNECT request to otel proxy: %w", err)
+	}
+
+	resp, err := http.ReadResponse(bufio.NewReader(conn), connectReq)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to read CONNECT response from otel proxy: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		conn.Close()
+		return nil, fmt.Errorf("otel proxy CONNECT failed: %s", resp.Status)
+	}
+
+	return conn, nil
+}
+
+func basicAuth(userinfo *url.Userinfo) string {
+	password, _ := userinfo.Password()
+	return base64.StdEncoding.EncodeToString([]byte(userinfo.Username() + ":" + password))
+}
@@ -0,0 +1,272 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+	"github.com/open-policy-agent/opa/rego"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Decision is the result of evaluating a policy decision point: data.wasmcloud.link.allow or
+// data.wasmcloud.health.allow.
+type Decision struct {
+	RequestID string   `json:"requestId"`
+	Permitted bool     `json:"permitted"`
+	Reasons   []string `json:"reasons,omitempty"`
+}
+
+// ExternalPolicyService is consulted in addition to the embedded Rego evaluation when configured
+// with WithExternalPolicyService, e.g. to forward decisions to a central policy service for
+// auditing or a second opinion.
+type ExternalPolicyService interface {
+	Evaluate(ctx context.Context, decisionPoint string, input map[string]interface{}) (Decision, error)
+}
+
+// PolicyEngine evaluates Rego policy for link put/del and health check lifecycle events without
+// requiring a separate wasmcloud.policy NATS responder process.
+type PolicyEngine struct {
+	tracer trace.Tracer
+
+	mu      sync.RWMutex
+	modules map[string]string // module name -> source, for hot reload
+	queries map[string]rego.PreparedEvalQuery
+
+	cacheTTL time.Duration
+	cacheMu  sync.Mutex
+	cache    map[string]cachedDecision
+
+	external ExternalPolicyService
+}
+
+type cachedDecision struct {
+	decision  Decision
+	expiresAt time.Time
+}
+
+// decisionPoints maps the handler a policy applies to, to its Rego query.
+var decisionPoints = map[string]string{
+	"link_put":    "data.wasmcloud.link.allow",
+	"link_del":    "data.wasmcloud.link.allow",
+	"link_health": "data.wasmcloud.link.allow",
+	"health":      "data.wasmcloud.health.allow",
+}
+
+// PolicyEngineOption configures a PolicyEngine built with NewPolicyEngine.
+type PolicyEngineOption func(*policyEngineConfig) error
+
+type policyEngineConfig struct {
+	modules  map[string]string
+	cacheTTL time.Duration
+	external ExternalPolicyService
+}
+
+// WithRegoInline adds a Rego module from an inline source string, e.g. the value of the
+// "policy.rego_inline" HostData.Config entry.
+func WithRegoInline(moduleName, source string) PolicyEngineOption {
+	return func(cfg *policyEngineConfig) error {
+		cfg.modules[moduleName] = source
+		return nil
+	}
+}
+
+// WithRegoBundle loads Rego modules from a directory or bundle tarball on disk, e.g. resolved
+// from the "policy.bundle_url" HostData.Config entry when it's a file:// or plain path.
+func WithRegoBundle(path string) PolicyEngineOption {
+	return func(cfg *policyEngineConfig) error {
+		// rego.Load handles both a single file and a directory of .rego files; bundle contents
+		// are merged into the prepared query at NewPolicyEngine time.
+		cfg.modules["bundle:"+path] = path
+		return nil
+	}
+}
+
+// WithDecisionCacheTTL controls how long an identical link/health input is cached before being
+// re-evaluated. The default is 10 seconds.
+func WithDecisionCacheTTL(ttl time.Duration) PolicyEngineOption {
+	return func(cfg *policyEngineConfig) error {
+		cfg.cacheTTL = ttl
+		return nil
+	}
+}
+
+// WithExternalPolicyService additionally forwards every decision to an external policy service.
+// If the external service denies a request the embedded engine permitted, the request is denied;
+// its reasons are merged into the returned Decision.
+func WithExternalPolicyService(svc ExternalPolicyService) PolicyEngineOption {
+	return func(cfg *policyEngineConfig) error {
+		cfg.external = svc
+		return nil
+	}
+}
+
+// NewPolicyEngine builds a PolicyEngine from the given options, preparing (and caching) one
+// compiled query per decision point up front.
+func NewPolicyEngine(tracer trace.Tracer, opts ...PolicyEngineOption) (*PolicyEngine, error) {
+	cfg := &policyEngineConfig{
+		modules:  map[string]string{},
+		cacheTTL: 10 * time.Second,
+	}
+	for _, opt := range opts {
+		if err := opt(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	engine := &PolicyEngine{
+		tracer:   tracer,
+		modules:  cfg.modules,
+		cacheTTL: cfg.cacheTTL,
+		cache:    map[string]cachedDecision{},
+		external: cfg.external,
+	}
+
+	if err := engine.reload(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return engine, nil
+}
+
+// LoadPolicyEngineFromConfig builds a PolicyEngine from HostData.Config entries:
+// "policy.rego_inline" for an inline module, or "policy.bundle_url" for a path to a bundle or
+// directory of .rego files on disk. Returns (nil, nil) if neither is configured, so providers can
+// treat policy enforcement as optional.
+func LoadPolicyEngineFromConfig(tracer trace.Tracer, hostData HostData) (*PolicyEngine, error) {
+	var opts []PolicyEngineOption
+	if inline, ok := hostData.Config["policy.rego_inline"]; ok {
+		opts = append(opts, WithRegoInline("inline.rego", inline))
+	}
+	if bundleURL, ok := hostData.Config["policy.bundle_url"]; ok {
+		opts = append(opts, WithRegoBundle(bundleURL))
+	}
+	if len(opts) == 0 {
+		return nil, nil
+	}
+	return NewPolicyEngine(tracer, opts...)
+}
+
+// reload (re)compiles all configured modules into one prepared query per decision point. It's
+// called once from NewPolicyEngine and again whenever a wasmcloud.policy.reload message arrives.
+func (p *PolicyEngine) reload(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	preparedByQuery := map[string]rego.PreparedEvalQuery{}
+	queries := make(map[string]rego.PreparedEvalQuery, len(decisionPoints))
+	for handler, query := range decisionPoints {
+		prepared, ok := preparedByQuery[query]
+		if !ok {
+			var moduleOpts []func(*rego.Rego)
+			moduleOpts = append(moduleOpts, rego.Query(query))
+			for name, source := range p.modules {
+				if len(name) > 7 && name[:7] == "bundle:" {
+					moduleOpts = append(moduleOpts, rego.Load([]string{source}, nil))
+				} else {
+					moduleOpts = append(moduleOpts, rego.Module(name, source))
+				}
+			}
+
+			var err error
+			prepared, err = rego.New(moduleOpts...).PrepareForEval(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to prepare policy query %q: %w", query, err)
+			}
+			preparedByQuery[query] = prepared
+		}
+		queries[handler] = prepared
+	}
+
+	p.queries = queries
+	// Invalidate cached decisions; they were evaluated against the policy we just replaced.
+	p.cacheMu.Lock()
+	p.cache = map[string]cachedDecision{}
+	p.cacheMu.Unlock()
+
+	return nil
+}
+
+// SubscribeReload listens on subject (conventionally "wasmcloud.policy.reload") for hot-reload
+// requests. The message payload, if non-empty, replaces the inline policy module; an empty
+// payload simply re-prepares the existing modules.
+func (p *PolicyEngine) SubscribeReload(nc *nats.Conn, subject string, logger interface{ Error(string, ...any) }) (*nats.Subscription, error) {
+	return nc.Subscribe(subject, func(m *nats.Msg) {
+		if len(m.Data) > 0 {
+			p.mu.Lock()
+			p.modules["inline.rego"] = string(m.Data)
+			p.mu.Unlock()
+		}
+		if err := p.reload(context.Background()); err != nil {
+			logger.Error("failed to reload policy engine", "error", err)
+		}
+	})
+}
+
+// Evaluate runs the decision point's prepared query against input, consulting the decision cache
+// first and the configured ExternalPolicyService (if any) afterward. decisionPoint is one of
+// "link_put", "link_del", "link_health", or "health".
+func (p *PolicyEngine) Evaluate(ctx context.Context, decisionPoint string, input map[string]interface{}) (Decision, error) {
+	ctx, span := p.tracer.Start(ctx, "policy."+decisionPoint)
+	defer span.End()
+
+	requestID := newRequestID(input)
+	cacheKey := decisionPoint + ":" + requestID
+
+	p.cacheMu.Lock()
+	if cached, ok := p.cache[cacheKey]; ok && time.Now().Before(cached.expiresAt) {
+		p.cacheMu.Unlock()
+		return cached.decision, nil
+	}
+	p.cacheMu.Unlock()
+
+	p.mu.RLock()
+	query, ok := p.queries[decisionPoint]
+	p.mu.RUnlock()
+	if !ok {
+		return Decision{}, fmt.Errorf("no policy configured for decision point %q", decisionPoint)
+	}
+
+	results, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return Decision{}, fmt.Errorf("failed to evaluate policy for %q: %w", decisionPoint, err)
+	}
+
+	decision := Decision{RequestID: requestID, Permitted: len(results) > 0 && results[0].Expressions[0].Value == true}
+	if !decision.Permitted {
+		decision.Reasons = append(decision.Reasons, fmt.Sprintf("denied by policy for decision point %q", decisionPoint))
+	}
+
+	if p.external != nil {
+		externalDecision, err := p.external.Evaluate(ctx, decisionPoint, input)
+		if err != nil {
+			return Decision{}, fmt.Errorf("external policy service failed: %w", err)
+		}
+		if !externalDecision.Permitted {
+			decision.Permitted = false
+			decision.Reasons = append(decision.Reasons, externalDecision.Reasons...)
+		}
+	}
+
+	p.cacheMu.Lock()
+	p.cache[cacheKey] = cachedDecision{decision: decision, expiresAt: time.Now().Add(p.cacheTTL)}
+	p.cacheMu.Unlock()
+
+	return decision, nil
+}
+
+// newRequestID derives a stable request ID for input by hashing its canonical JSON encoding, used
+// both as the Decision.RequestID and the decision cache key.
+func newRequestID(input map[string]interface{}) string {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -1,12 +1,10 @@
 package provider
 
 import (
-	"bufio"
 	"context"
-	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -18,7 +16,7 @@ import (
 	nats "github.com/nats-io/nats.go"
 	"github.com/nats-io/nkeys"
 	"go.opentelemetry.io/otel"
-	"go.opentelemetry.io/otel/log/global"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type WasmcloudProvider struct {
@@ -36,21 +34,38 @@ type WasmcloudProvider struct {
 
 	RPCClient *wrpcnats.Client
 
+	// tracer is used to start spans for lattice control-plane messages (health, link put/del,
+	// shutdown) that carry a propagated W3C tracecontext. See SetupOtel.
+	tracer trace.Tracer
+	// otel owns the live TracerProvider/MeterProvider/LoggerProvider and lets them be rebuilt at
+	// runtime in response to a LATTICE_OTEL_CONFIG message. See OtelManager.Reconfigure.
+	otel *OtelManager
+
 	natsConnection    *nats.Conn
 	natsSubscriptions map[string]*nats.Subscription
 
-	healthMsgFunc func() string
+	healthMsgFunc func(context.Context) string
 
-	shutdownFunc func() error
+	shutdownFunc func(context.Context) error
 	// internalShutdownFuncs holds a list of callbacks triggered during shutdown (ex: opentelemetry exporter graceful shutdown).
 	// They are called after the user provided `shutdownFunc` and nats disconnect.
 	internalShutdownFuncs []func(context.Context) error
 	shutdown              chan struct{}
 
-	putSourceLinkFunc func(InterfaceLinkDefinition) error
-	putTargetLinkFunc func(InterfaceLinkDefinition) error
-	delSourceLinkFunc func(InterfaceLinkDefinition) error
-	delTargetLinkFunc func(InterfaceLinkDefinition) error
+	putSourceLinkFunc func(context.Context, InterfaceLinkDefinition) error
+	putTargetLinkFunc func(context.Context, InterfaceLinkDefinition) error
+	delSourceLinkFunc func(context.Context, InterfaceLinkDefinition) error
+	delTargetLinkFunc func(context.Context, InterfaceLinkDefinition) error
+
+	// configUpdateFunc runs on every LATTICE_CONFIG_UPDATE message, when set via ConfigUpdate.
+	configUpdateFunc func(context.Context, map[string]string) error
+	// linkHealthFunc answers LATTICE_LINK_HEALTH queries, when set via LinkHealthCheck.
+	linkHealthFunc func(context.Context, InterfaceLinkDefinition) LinkHealth
+	// onReadyFunc runs once, after startup linkdefs are applied and before Start subscribes to
+	// NATS, when set via OnReady.
+	onReadyFunc func(context.Context) error
+	// onEventFunc receives every message on LATTICE_EVENTS, when set via OnEvent.
+	onEventFunc func(context.Context, ProviderEvent)
 
 	lock sync.Mutex
 	// Links from the provider to other components, aka where the provider is the
@@ -59,36 +74,70 @@ type WasmcloudProvider struct {
 	// Links from other components to the provider, aka where the provider is the
 	// target of the link. Indexed by the component ID of the source
 	targetLinks map[string]InterfaceLinkDefinition
+
+	// metrics holds the Prometheus collectors configured via WithPrometheusMetrics or
+	// WithMetricsRegistry. Nil if the provider wasn't configured with either option.
+	metrics *providerMetrics
+
+	// policyEngine enforces Rego policy on link put/del and health check events ahead of the
+	// user-provided handlers, when configured via WithPolicyEngine.
+	policyEngine *PolicyEngine
+
+	// jwtAuth validates signed RPC auth tokens on lattice control messages ahead of the
+	// user-provided handlers, when HostData.RPCAuthEnabled is set. Nil (a no-op) otherwise.
+	jwtAuth *JWTAuth
+
+	// linkMiddleware holds the Hooks registered per Phase via Use, run by runLinkPipeline around
+	// every link put/del.
+	linkMiddleware map[Phase][]Hook
+
+	// transport is the lattice control-plane channel Start subscribes on and Shutdown tears down.
+	// Defaults to NatsTransport, or whatever HostData.RPCTransport selects; override with
+	// WithTransport. See Transport's doc comment for what it does and doesn't cover.
+	transport Transport
+
+	// events is the async bus subToNats publishes LinkPutEvent/LinkDelEvent/HealthCheckEvent/
+	// ShutdownEvent onto. Link put/del events replace calling putSourceLinkFunc and friends
+	// directly (see registerDefaultEventSubscribers); health and shutdown events are published
+	// after healthMsgFunc/shutdownFunc have already run.
+	events *EventBus
+
+	// loggerConfig, when set via WithLogger, is the format/per-name level/sampling/OTEL fan-out
+	// config LoggerFor builds named loggers from. Nil (the default) means Logger and LoggerFor
+	// both just use the HostData.StructuredLogging/HostData.LogLevel handler built in New.
+	loggerConfig *LoggerConfig
+
+	// inflightRPCs tracks wit-generated handler methods currently running, via the span wrapper
+	// StartRPCSpan returns; Shutdown's ShutdownPhaseDrainRPC phase waits on it.
+	inflightRPCs sync.WaitGroup
+
+	// shutdownTimeout bounds Shutdown's overall deadline when the context it's called with has
+	// none; set via WithShutdownTimeout. Zero means defaultShutdownTimeout.
+	shutdownTimeout time.Duration
+	// shutdownHooks holds the ShutdownPhase-keyed funcs WithShutdownHook registers, run by
+	// Shutdown after the phase's own built-in work.
+	shutdownHooks map[ShutdownPhase][]func(context.Context) error
+
+	// cluster, when set via WithCluster or WithClustering, gates whether putLink/deleteLink
+	// actually invoke the user-provided link lifecycle callbacks on this instance; see
+	// Cluster.ShouldHandleLink. Nil (the default) runs every callback unconditionally, as if every
+	// instance were its own single-member cluster.
+	cluster *Cluster
+
+	// sourceLinkSelector and targetLinkSelector hold the round-robin cursor, outstanding-request
+	// counts, and weighted-random alias table PickSourceLink/PickTargetLink need across calls. See
+	// linkselect.go.
+	sourceLinkSelector *linkSelector
+	targetLinkSelector *linkSelector
 }
 
 func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
-	reader := bufio.NewReader(os.Stdin)
-
-	// Make a channel to receive the host data so we can timeout if we don't receive it
-	// All host data is sent immediately after the provider starts
-	hostDataChannel := make(chan string, 1)
-	go func() {
-		hostDataRaw, err := reader.ReadString('\n')
-		if err != nil {
-			log.Fatal(err)
-		}
-		hostDataChannel <- hostDataRaw
-	}()
-
-	hostData := HostData{}
-	select {
-	case hostDataRaw := <-hostDataChannel:
-		decodedData, err := base64.StdEncoding.DecodeString(hostDataRaw)
-		if err != nil {
-			return nil, err
-		}
-
-		err = json.Unmarshal(decodedData, &hostData)
-		if err != nil {
-			return nil, err
-		}
-	case <-time.After(5 * time.Second):
-		log.Fatal("failed to read host data, did not receive after 5 seconds")
+	// LoadHostData supports JSON, YAML, and TOML host data, sourced from either stdin (as sent by
+	// the wasmCloud host) or WASMCLOUD_HOST_DATA_FILE. It is called with no options here, so
+	// decoders registered via WithHostDataDecoder are not used by New.
+	hostData, err := LoadHostData()
+	if err != nil {
+		return nil, err
 	}
 
 	// Initialize Logging
@@ -108,39 +157,16 @@ func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
 	var internalShutdownFuncs []func(context.Context) error
 
 	// Initialize Observability
-	propagator := newPropagator()
-	otel.SetTextMapPropagator(propagator)
-
-	serviceResource, err := newServiceResource(context.Background(), hostData.ProviderKey)
+	otelManager, err := SetupOtel(context.Background(), hostData)
 	if err != nil {
 		return nil, err
 	}
+	internalShutdownFuncs = append(internalShutdownFuncs, otelManager.Shutdown)
+	tracer := otel.Tracer(fmt.Sprintf("wasmcloud-provider-%s", hostData.ProviderKey))
 
-	if hostData.OtelConfig.EnableObservability || (hostData.OtelConfig.EnableMetrics != nil && *hostData.OtelConfig.EnableMetrics) {
-		meterProvider, err := newMeterProvider(context.Background(), hostData.OtelConfig, serviceResource)
-		if err != nil {
-			return nil, err
-		}
-		otel.SetMeterProvider(meterProvider)
-		internalShutdownFuncs = append(internalShutdownFuncs, func(c context.Context) error { return meterProvider.Shutdown(c) })
-	}
-
-	if hostData.OtelConfig.EnableObservability || (hostData.OtelConfig.EnableTraces != nil && *hostData.OtelConfig.EnableTraces) {
-		tracerProvider, err := newTracerProvider(context.Background(), hostData.OtelConfig, serviceResource)
-		if err != nil {
-			return nil, err
-		}
-		otel.SetTracerProvider(tracerProvider)
-		internalShutdownFuncs = append(internalShutdownFuncs, func(c context.Context) error { return tracerProvider.Shutdown(c) })
-	}
-
-	if hostData.OtelConfig.EnableObservability || (hostData.OtelConfig.EnableLogs != nil && *hostData.OtelConfig.EnableLogs) {
-		loggerProvider, err := newLoggerProvider(context.Background(), hostData.OtelConfig, serviceResource)
-		if err != nil {
-			return nil, err
-		}
-		global.SetLoggerProvider(loggerProvider)
-		internalShutdownFuncs = append(internalShutdownFuncs, func(c context.Context) error { return loggerProvider.Shutdown(c) })
+	jwtAuth, err := LoadJWTAuthFromHostData(hostData, logger)
+	if err != nil {
+		return nil, err
 	}
 
 	// Connect to NATS
@@ -201,8 +227,13 @@ func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
 	prefix := fmt.Sprintf("%s.%s", hostData.LatticeRPCPrefix, hostData.ProviderKey)
 	wrpc := wrpcnats.NewClientWithQueueGroup(nc, prefix, prefix)
 
+	transport, err := transportFromHostData(hostData)
+	if err != nil {
+		return nil, err
+	}
+
 	signalCh := make(chan os.Signal, 1)
-	signal.Notify(signalCh, syscall.SIGINT)
+	signal.Notify(signalCh, syscall.SIGINT, syscall.SIGTERM)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	provider := &WasmcloudProvider{
@@ -210,6 +241,10 @@ func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
 		Logger:    logger,
 		RPCClient: wrpc,
 		Topics:    LatticeTopics(hostData, providerXkey),
+		tracer:    tracer,
+		otel:      otelManager,
+		jwtAuth:   jwtAuth,
+		transport: transport,
 
 		context: ctx,
 		cancel:  cancel,
@@ -221,19 +256,61 @@ func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
 		natsConnection:    nc,
 		natsSubscriptions: map[string]*nats.Subscription{},
 
-		healthMsgFunc: func() string { return "healthy" },
+		healthMsgFunc: func(context.Context) string { return "healthy" },
 
-		shutdownFunc:          func() error { return nil },
+		shutdownFunc:          func(context.Context) error { return nil },
 		internalShutdownFuncs: internalShutdownFuncs,
 		shutdown:              make(chan struct{}),
 
-		putSourceLinkFunc: func(InterfaceLinkDefinition) error { return nil },
-		putTargetLinkFunc: func(InterfaceLinkDefinition) error { return nil },
-		delSourceLinkFunc: func(InterfaceLinkDefinition) error { return nil },
-		delTargetLinkFunc: func(InterfaceLinkDefinition) error { return nil },
+		putSourceLinkFunc: func(context.Context, InterfaceLinkDefinition) error { return nil },
+		putTargetLinkFunc: func(context.Context, InterfaceLinkDefinition) error { return nil },
+		delSourceLinkFunc: func(context.Context, InterfaceLinkDefinition) error { return nil },
+		delTargetLinkFunc: func(context.Context, InterfaceLinkDefinition) error { return nil },
+
+		configUpdateFunc: func(context.Context, map[string]string) error { return nil },
+		linkHealthFunc:   func(context.Context, InterfaceLinkDefinition) LinkHealth { return LinkHealth{Healthy: true} },
+		onReadyFunc:      func(context.Context) error { return nil },
+		onEventFunc:      func(context.Context, ProviderEvent) {},
 
 		sourceLinks: make(map[string]InterfaceLinkDefinition, len(sourceLinks)),
 		targetLinks: make(map[string]InterfaceLinkDefinition, len(targetLinks)),
+
+		linkMiddleware: make(map[Phase][]Hook),
+		shutdownHooks:  make(map[ShutdownPhase][]func(context.Context) error),
+
+		sourceLinkSelector: newLinkSelector(),
+		targetLinkSelector: newLinkSelector(),
+	}
+
+	provider.events = newEventBus(logger)
+	provider.registerDefaultEventSubscribers()
+	provider.registerLinkSelectionInvalidation()
+
+	// Configure the default decryption context for InterfaceLinkDefinition.UnmarshalJSON; options
+	// below may override it via WithSecretsXKey.
+	setSecretsXKeyContext(providerXkey, hostData.HostXKeyPublicKey)
+
+	// WASMCLOUD_LOG_SINKS lets an operator attach additional log destinations (syslog, a local
+	// NDJSON file, an OTLP collector) without the provider binary needing a WithLogSinks call of
+	// its own; see logSinksFromEnv. It's applied before the options below, so a provider binary
+	// that also calls WithLogSinks explicitly ends up with both sets of sinks.
+	if envSinks := os.Getenv("WASMCLOUD_LOG_SINKS"); envSinks != "" {
+		sinks, err := logSinksFromEnv(envSinks)
+		if err != nil {
+			return nil, err
+		}
+		if err := WithLogSinks(sinks...)(provider); err != nil {
+			return nil, err
+		}
+	}
+
+	// HostData.ClusterEnabled lets an operator turn on clustering without the provider binary
+	// needing a WithClustering call of its own; an explicit WithCluster/WithClustering option
+	// below overrides it.
+	if hostData.ClusterEnabled {
+		if err := WithClustering()(provider); err != nil {
+			return nil, err
+		}
 	}
 
 	for _, opt := range options {
@@ -246,10 +323,14 @@ func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
 	for _, link := range sourceLinks {
 		decryptedLink, err := provider.DecryptLinkSecrets(link)
 		if err != nil {
+			// ErrSecretsIncomplete shouldn't happen for links supplied via HostData (they aren't
+			// subject to the NATS chunking in secrets.go), but treat it the same as any other
+			// decrypt failure: decryptedLink isn't usable, so don't hand it to
+			// updateProviderLinkMap.
 			logger.Error("failed to decrypt secrets on link", slog.Any("error", err))
+			continue
 		}
-		err = provider.updateProviderLinkMap(decryptedLink)
-		if err != nil {
+		if err := provider.updateProviderLinkMap(decryptedLink); err != nil {
 			logger.Error("failed to update provider link map", slog.Any("error", err))
 		}
 	}
@@ -258,9 +339,9 @@ func New(options ...ProviderHandler) (*WasmcloudProvider, error) {
 		decryptedLink, err := provider.DecryptLinkSecrets(link)
 		if err != nil {
 			logger.Error("failed to decrypt secrets on link", slog.Any("error", err))
+			continue
 		}
-		err = provider.updateProviderLinkMap(decryptedLink)
-		if err != nil {
+		if err := provider.updateProviderLinkMap(decryptedLink); err != nil {
 			logger.Error("failed to update provider link map", slog.Any("error", err))
 		}
 	}
@@ -280,20 +361,30 @@ func (wp *WasmcloudProvider) OutgoingRpcClient(target string) *wrpcnats.Client {
 }
 
 func (wp *WasmcloudProvider) Start() error {
+	if wp.cluster != nil {
+		if err := wp.cluster.Start(wp.context); err != nil {
+			return err
+		}
+	}
+
 	for _, link := range wp.sourceLinks {
-		err := wp.putSourceLinkFunc(link)
+		err := wp.putSourceLinkFunc(wp.context, link)
 		if err != nil {
 			wp.Logger.Error("failed to invoke source link function", slog.Any("error", err))
 		}
 	}
 	for _, link := range wp.targetLinks {
-		err := wp.putTargetLinkFunc(link)
+		err := wp.putTargetLinkFunc(wp.context, link)
 		if err != nil {
 			wp.Logger.Error("failed to invoke target link function", slog.Any("error", err))
 		}
 	}
 
-	err := wp.subToNats()
+	if err := wp.onReadyFunc(wp.context); err != nil {
+		wp.Logger.Error("onReady handler failed", slog.Any("error", err))
+	}
+
+	err := wp.transport.Subscribe(wp)
 	if err != nil {
 		return err
 	}
@@ -304,39 +395,23 @@ func (wp *WasmcloudProvider) Start() error {
 	return nil
 }
 
-func (wp *WasmcloudProvider) Shutdown() error {
-	err := wp.shutdownFunc()
-	if err != nil {
-		wp.cancel()
-		return err
-	}
-
-	err = wp.cleanupNatsSubscriptions()
-	if err != nil {
-		wp.cancel()
-		return err
-	}
-
-	for _, errFunc := range wp.internalShutdownFuncs {
-		if err := errFunc(wp.context); err != nil {
-			wp.cancel()
-			return err
-		}
-	}
-
-	wp.cancel()
-	return nil
-}
-
 func (wp *WasmcloudProvider) subToNats() error {
 	// ------------------ Subscribe to Health topic --------------------
-	health, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_HEALTH,
+	health, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_HEALTH, wp.jwtAuth.Wrap(
 		func(m *nats.Msg) {
-			msg := wp.healthMsgFunc()
+			ctx, span := wp.startSpanFromNatsMsg(m, "health_check")
+			defer span.End()
+
+			if !wp.checkPolicy(ctx, "health", map[string]interface{}{"providerId": wp.Id}) {
+				return
+			}
+
+			msg := wp.healthMsgFunc(ctx)
 			hc := HealthCheckResponse{
 				Healthy: true,
 				Message: msg,
 			}
+			wp.recordHealthcheckStatus(hc.Healthy)
 
 			hcBytes, err := json.Marshal(hc)
 			if err != nil {
@@ -348,7 +423,11 @@ func (wp *WasmcloudProvider) subToNats() error {
 			if err != nil {
 				wp.Logger.Error("failed to publish health check response", slog.Any("error", err))
 			}
-		})
+
+			// Published after the reply is already on its way: a HealthCheckEvent subscriber
+			// (audit logging, an external metrics sink) has no business holding up the response.
+			wp.events.Publish(ctx, HealthCheckEvent{Message: msg})
+		}))
 	if err != nil {
 		wp.Logger.Error("LATTICE_HEALTH", slog.Any("error", err))
 		return err
@@ -357,8 +436,11 @@ func (wp *WasmcloudProvider) subToNats() error {
 	wp.natsSubscriptions[wp.Topics.LATTICE_HEALTH] = health
 
 	// ------------------ Subscribe to Delete link topic --------------
-	linkDel, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_LINK_DEL,
+	linkDel, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_LINK_DEL, wp.jwtAuth.Wrap(
 		func(m *nats.Msg) {
+			ctx, span := wp.startSpanFromNatsMsg(m, "link_del")
+			defer span.End()
+
 			link := InterfaceLinkDefinition{}
 			err := json.Unmarshal(m.Data, &link)
 			if err != nil {
@@ -366,13 +448,15 @@ func (wp *WasmcloudProvider) subToNats() error {
 				return
 			}
 
-			err = wp.deleteLink(link)
-			if err != nil {
-				// TODO(#10): handle better?
-				wp.Logger.Error("failed to delete link", slog.Any("error", err))
+			if !wp.checkPolicy(ctx, "link_del", linkPolicyInput(link)) {
 				return
 			}
-		})
+
+			// EventLinkDel's default subscriber (see registerDefaultEventSubscribers) is
+			// wp.deleteLink; publishing here instead of calling it directly lets provider authors
+			// add their own subscribers without monkey-patching this callback.
+			wp.events.Publish(ctx, LinkDelEvent{Link: link})
+		}))
 	if err != nil {
 		wp.Logger.Error("LINK_DEL", slog.Any("error", err))
 		return err
@@ -381,27 +465,37 @@ func (wp *WasmcloudProvider) subToNats() error {
 	wp.natsSubscriptions[wp.Topics.LATTICE_LINK_DEL] = linkDel
 
 	// ------------------ Subscribe to New link topic --------------
-	linkPut, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_LINK_PUT,
+	linkPut, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_LINK_PUT, wp.jwtAuth.Wrap(
 		func(m *nats.Msg) {
-			link := linkWithEncryptedSecrets{}
+			ctx, span := wp.startSpanFromNatsMsg(m, "link_put")
+			defer span.End()
+
+			// InterfaceLinkDefinition.UnmarshalJSON decrypts SourceSecrets/TargetSecrets as part
+			// of decoding, using the xkey configured in setSecretsXKeyContext.
+			link := InterfaceLinkDefinition{}
 			err := json.Unmarshal(m.Data, &link)
+			if errors.Is(err, ErrSecretsIncomplete) {
+				// This message carries one part of a chunked secrets payload; more parts are
+				// still in flight. Don't treat this as a link to enroll or a decode failure —
+				// the completing chunk arrives as another LATTICE_LINK_PUT message, which will
+				// unmarshal cleanly and get published below.
+				wp.Logger.Debug("awaiting more chunks of link secrets", slog.Any("error", err))
+				return
+			}
 			if err != nil {
 				wp.Logger.Error("failed to decode link", slog.Any("error", err))
 				return
 			}
 
-			providerLink, err := wp.DecryptLinkSecrets(link)
-			if err != nil {
-				wp.Logger.Error("failed to decrypt secrets on link", slog.Any("error", err))
+			if !wp.checkPolicy(ctx, "link_put", linkPolicyInput(link)) {
 				return
 			}
 
-			err = wp.putLink(providerLink)
-			if err != nil {
-				// TODO(#10): handle this better?
-				wp.Logger.Error("newLinkFunc", slog.Any("error", err))
-			}
-		})
+			// EventLinkPut's default subscriber (see registerDefaultEventSubscribers) is
+			// wp.putLink; publishing here instead of calling it directly lets provider authors
+			// add their own subscribers without monkey-patching this callback.
+			wp.events.Publish(ctx, LinkPutEvent{Link: link})
+		}))
 	if err != nil {
 		wp.Logger.Error("LINK_PUT", slog.Any("error", err))
 		return err
@@ -410,14 +504,21 @@ func (wp *WasmcloudProvider) subToNats() error {
 	wp.natsSubscriptions[wp.Topics.LATTICE_LINK_PUT] = linkPut
 
 	// ------------------ Subscribe to Shutdown topic ------------------
-	shutdown, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_SHUTDOWN,
+	shutdown, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_SHUTDOWN, wp.jwtAuth.Wrap(
 		func(m *nats.Msg) {
-			err := wp.shutdownFunc()
+			ctx, span := wp.startSpanFromNatsMsg(m, "shutdown")
+			defer span.End()
+
+			err := wp.shutdownFunc(ctx)
 			if err != nil {
 				// TODO(#10): handle this better?
 				wp.Logger.Error("ERROR: provider shutdown function failed: " + err.Error())
 			}
 
+			// Published after shutdownFunc runs, same as HealthCheckEvent: a ShutdownEvent
+			// subscriber has no business holding up the reply or the rest of the shutdown phases.
+			wp.events.Publish(ctx, ShutdownEvent{})
+
 			err = m.Respond([]byte("provider shutdown handled successfully"))
 			if err != nil {
 				// NOTE: This is a log message because we don't want to stop the shutdown process
@@ -430,13 +531,137 @@ func (wp *WasmcloudProvider) subToNats() error {
 			}
 
 			wp.cancel()
-		})
+		}))
 	if err != nil {
 		wp.Logger.Error("LATTICE_SHUTDOWN", slog.Any("error", err))
 		return err
 	}
 
 	wp.natsSubscriptions[wp.Topics.LATTICE_SHUTDOWN] = shutdown
+
+	// ------------------ Subscribe to RPC auth JWKS rotation -----------
+	if wp.jwtAuth != nil {
+		jwksSub, err := wp.jwtAuth.SubscribeRotation(wp.natsConnection, wp.Topics.LATTICE_RPC_AUTH_JWKS)
+		if err != nil {
+			wp.Logger.Error("RPC_AUTH_JWKS", slog.Any("error", err))
+			return err
+		}
+		wp.natsSubscriptions[wp.Topics.LATTICE_RPC_AUTH_JWKS] = jwksSub
+	}
+
+	// ------------------ Subscribe to Link health topic ---------------
+	linkHealth, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_LINK_HEALTH, wp.jwtAuth.Wrap(
+		func(m *nats.Msg) {
+			ctx, span := wp.startSpanFromNatsMsg(m, "link_health_check")
+			defer span.End()
+
+			link := InterfaceLinkDefinition{}
+			if err := json.Unmarshal(m.Data, &link); err != nil {
+				wp.Logger.Error("failed to decode link", slog.Any("error", err))
+				return
+			}
+
+			if !wp.checkPolicy(ctx, "link_health", linkPolicyInput(link)) {
+				return
+			}
+
+			health := wp.linkHealthFunc(ctx, link)
+
+			healthBytes, err := json.Marshal(health)
+			if err != nil {
+				wp.Logger.Error("failed to encode link health", slog.Any("error", err))
+				return
+			}
+
+			if err := wp.natsConnection.Publish(m.Reply, healthBytes); err != nil {
+				wp.Logger.Error("failed to publish link health response", slog.Any("error", err))
+			}
+		}))
+	if err != nil {
+		wp.Logger.Error("LATTICE_LINK_HEALTH", slog.Any("error", err))
+		return err
+	}
+
+	wp.natsSubscriptions[wp.Topics.LATTICE_LINK_HEALTH] = linkHealth
+
+	// ------------------ Subscribe to Config update topic --------------
+	configUpdate, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_CONFIG_UPDATE, wp.jwtAuth.Wrap(
+		func(m *nats.Msg) {
+			ctx, span := wp.startSpanFromNatsMsg(m, "config_update")
+			defer span.End()
+
+			var config map[string]string
+			if err := json.Unmarshal(m.Data, &config); err != nil {
+				wp.Logger.Error("failed to decode config update", slog.Any("error", err))
+				return
+			}
+
+			if err := wp.configUpdateFunc(ctx, config); err != nil {
+				wp.Logger.Error("config update handler failed", slog.Any("error", err))
+			}
+		}))
+	if err != nil {
+		wp.Logger.Error("LATTICE_CONFIG_UPDATE", slog.Any("error", err))
+		return err
+	}
+
+	wp.natsSubscriptions[wp.Topics.LATTICE_CONFIG_UPDATE] = configUpdate
+
+	// ------------------ Subscribe to lattice control-plane events -----
+	events, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_EVENTS, func(m *nats.Msg) {
+		event := ProviderEvent{Subject: m.Subject}
+		if err := json.Unmarshal(m.Data, &event); err != nil {
+			wp.Logger.Error("failed to decode lattice event", "subject", m.Subject, slog.Any("error", err))
+			return
+		}
+		event.Subject = m.Subject
+
+		wp.onEventFunc(wp.context, event)
+	})
+	if err != nil {
+		wp.Logger.Error("LATTICE_EVENTS", slog.Any("error", err))
+		return err
+	}
+
+	wp.natsSubscriptions[wp.Topics.LATTICE_EVENTS] = events
+
+	// ------------------ Subscribe to OTel reconfiguration topic -------
+	otelConfig, err := wp.natsConnection.Subscribe(wp.Topics.LATTICE_OTEL_CONFIG, wp.jwtAuth.Wrap(
+		func(m *nats.Msg) {
+			ctx, span := wp.startSpanFromNatsMsg(m, "otel_config")
+			defer span.End()
+
+			var update OtelConfigUpdate
+			if err := json.Unmarshal(m.Data, &update); err != nil {
+				wp.Logger.Error("failed to decode otel config update", slog.Any("error", err))
+				return
+			}
+
+			hc := HealthCheckResponse{Healthy: true, Message: "otel reconfiguration applied"}
+			if err := wp.otel.Reconfigure(ctx, update); err != nil {
+				wp.Logger.Error("otel reconfiguration failed", slog.Any("error", err))
+				hc = HealthCheckResponse{Healthy: false, Message: err.Error()}
+			}
+
+			if m.Reply == "" {
+				return
+			}
+			hcBytes, err := json.Marshal(hc)
+			if err != nil {
+				wp.Logger.Error("failed to encode otel reconfiguration result", slog.Any("error", err))
+				return
+			}
+			if err := wp.natsConnection.Publish(m.Reply, hcBytes); err != nil {
+				wp.Logger.Error("failed to publish otel reconfiguration result", slog.Any("error", err))
+			}
+		}))
+	if err != nil {
+		wp.Logger.Error("LATTICE_OTEL_CONFIG", slog.Any("error", err))
+		return err
+	}
+
+	wp.natsSubscriptions[wp.Topics.LATTICE_OTEL_CONFIG] = otelConfig
+
 	return nil
 }
 
@@ -482,7 +707,7 @@ func (wp *WasmcloudProvider) DecryptLinkSecrets(h linkWithEncryptedSecrets) (Int
 	}, nil
 }
 
-func (wp *WasmcloudProvider) putLink(l InterfaceLinkDefinition) error {
+func (wp *WasmcloudProvider) putLink(ctx context.Context, l InterfaceLinkDefinition) error {
 	// Ignore duplicate links
 	if wp.isLinked(l.SourceID, l.Target) {
 		wp.Logger.Info("ignoring duplicate link", "link", l)
@@ -492,14 +717,26 @@ func (wp *WasmcloudProvider) putLink(l InterfaceLinkDefinition) error {
 	wp.lock.Lock()
 	defer wp.lock.Unlock()
 	if l.SourceID == wp.Id {
-		err := wp.putSourceLinkFunc(l)
+		if wp.cluster != nil && !wp.cluster.ShouldHandleLink(l) {
+			wp.sourceLinks[l.Target] = l
+			return nil
+		}
+
+		err := wp.runLinkPipeline(ctx, l, wp.putSourceLinkFunc)
+		wp.recordLinkOp("source", "put", err)
 		if err != nil {
 			return err
 		}
 
 		wp.sourceLinks[l.Target] = l
 	} else if l.Target == wp.Id {
-		err := wp.putTargetLinkFunc(l)
+		if wp.cluster != nil && !wp.cluster.ShouldHandleLink(l) {
+			wp.targetLinks[l.SourceID] = l
+			return nil
+		}
+
+		err := wp.runLinkPipeline(ctx, l, wp.putTargetLinkFunc)
+		wp.recordLinkOp("target", "put", err)
 		if err != nil {
 			return err
 		}
@@ -529,18 +766,30 @@ func (wp *WasmcloudProvider) updateProviderLinkMap(l InterfaceLinkDefinition) er
 	return nil
 }
 
-func (wp *WasmcloudProvider) deleteLink(l InterfaceLinkDefinition) error {
+func (wp *WasmcloudProvider) deleteLink(ctx context.Context, l InterfaceLinkDefinition) error {
 	wp.lock.Lock()
 	defer wp.lock.Unlock()
 	if l.SourceID == wp.Id {
-		err := wp.delSourceLinkFunc(l)
+		if wp.cluster != nil && !wp.cluster.ShouldHandleLink(l) {
+			delete(wp.sourceLinks, l.Target)
+			return nil
+		}
+
+		err := wp.runLinkPipeline(ctx, l, wp.delSourceLinkFunc)
+		wp.recordLinkOp("source", "del", err)
 		if err != nil {
 			return err
 		}
 
 		delete(wp.sourceLinks, l.Target)
 	} else if l.Target == wp.Id {
-		err := wp.delTargetLinkFunc(l)
+		if wp.cluster != nil && !wp.cluster.ShouldHandleLink(l) {
+			delete(wp.targetLinks, l.SourceID)
+			return nil
+		}
+
+		err := wp.runLinkPipeline(ctx, l, wp.delTargetLinkFunc)
+		wp.recordLinkOp("target", "del", err)
 		if err != nil {
 			return err
 		}
@@ -553,6 +802,34 @@ func (wp *WasmcloudProvider) deleteLink(l InterfaceLinkDefinition) error {
 	return nil
 }
 
+// checkPolicy evaluates decisionPoint against wp.policyEngine, logging and returning false if the
+// engine denies the request. It returns true (permitting the caller to proceed) whenever no
+// policy engine is configured.
+func (wp *WasmcloudProvider) checkPolicy(ctx context.Context, decisionPoint string, input map[string]interface{}) bool {
+	if wp.policyEngine == nil {
+		return true
+	}
+
+	decision, err := wp.policyEngine.Evaluate(ctx, decisionPoint, input)
+	if err != nil {
+		wp.Logger.Error("failed to evaluate policy", "decisionPoint", decisionPoint, slog.Any("error", err))
+		return false
+	}
+	if !decision.Permitted {
+		wp.Logger.Warn("policy denied request", "decisionPoint", decisionPoint, "requestId", decision.RequestID, "reasons", decision.Reasons)
+		return false
+	}
+	return true
+}
+
+func linkPolicyInput(l InterfaceLinkDefinition) map[string]interface{} {
+	return map[string]interface{}{
+		"sourceId": l.SourceID,
+		"target":   l.Target,
+		"name":     l.Name,
+	}
+}
+
 func (wp *WasmcloudProvider) isLinked(sourceId string, target string) bool {
 	wp.lock.Lock()
 	defer wp.lock.Unlock()
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// SSMSecretBackend resolves secret references as AWS SSM Parameter Store parameter names.
+// Register it under the name "aws-ssm".
+type SSMSecretBackend struct {
+	Client         *ssm.Client
+	WithDecryption bool
+}
+
+// NewSSMSecretBackend builds an SSMSecretBackend from an AWS config, decrypting SecureString
+// parameters by default.
+func NewSSMSecretBackend(cfg aws.Config) *SSMSecretBackend {
+	return &SSMSecretBackend{Client: ssm.NewFromConfig(cfg), WithDecryption: true}
+}
+
+func (*SSMSecretBackend) Name() string { return "aws-ssm" }
+
+func (b *SSMSecretBackend) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	out, err := b.Client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(ref),
+		WithDecryption: aws.Bool(b.WithDecryption),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SSM parameter %q: %w", ref, err)
+	}
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return nil, fmt.Errorf("SSM parameter %q has no value", ref)
+	}
+	return []byte(*out.Parameter.Value), nil
+}
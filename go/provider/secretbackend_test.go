@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestSecretValueRefResolvesAndCaches(t *testing.T) {
+	backend := InMemorySecretBackend{BackendName: "test-backend", Values: map[string][]byte{
+		"/prod/db/password": []byte("hunter2"),
+	}}
+	RegisterSecretBackend(backend)
+	SecretBackendCacheTTL = time.Minute
+
+	jsonData := `{"kind": "Ref", "backend": "test-backend", "path": "/prod/db/password"}`
+	secret := &SecretValue{}
+	if err := json.Unmarshal([]byte(jsonData), secret); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	value, err := secret.Reveal()
+	if err != nil {
+		t.Fatalf("Expected err to be nil, got: %v", err)
+	}
+	if value != "hunter2" {
+		t.Errorf("Unexpected value. Got: %s, Expected: %s", value, "hunter2")
+	}
+
+	// Mutate the backing store directly; Reveal should still return the cached value.
+	backend.Values["/prod/db/password"] = []byte("rotated")
+	value, err = secret.Reveal()
+	if err != nil {
+		t.Fatalf("Expected err to be nil, got: %v", err)
+	}
+	if value != "hunter2" {
+		t.Errorf("Expected cached value to be returned. Got: %s, Expected: %s", value, "hunter2")
+	}
+}
+
+func TestSecretValueRefUnknownBackend(t *testing.T) {
+	jsonData := `{"kind": "Ref", "backend": "does-not-exist", "path": "/some/path"}`
+	secret := &SecretValue{}
+	if err := json.Unmarshal([]byte(jsonData), secret); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	if _, err := secret.Reveal(); err == nil {
+		t.Error("Expected an error resolving an unregistered backend, got nil")
+	}
+}
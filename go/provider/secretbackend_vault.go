@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	vault "github.com/hashicorp/vault/api"
+)
+
+// VaultSecretBackend resolves secret references against a HashiCorp Vault KV v2 mount. A ref has
+// the form "path#field", with path relative to Mount, e.g. "prod/db#password"; if "#field" is omitted, the
+// entire key/value map at path is returned as JSON.
+type VaultSecretBackend struct {
+	Client *vault.Client
+	Mount  string
+}
+
+// NewVaultSecretBackend builds a VaultSecretBackend from an already-authenticated Vault client,
+// reading from the given KV v2 mount (e.g. "secret").
+func NewVaultSecretBackend(client *vault.Client, mount string) *VaultSecretBackend {
+	return &VaultSecretBackend{Client: client, Mount: mount}
+}
+
+func (*VaultSecretBackend) Name() string { return "vault" }
+
+func (b *VaultSecretBackend) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	path, field, hasField := strings.Cut(ref, "#")
+
+	secret, err := b.Client.KVv2(b.Mount).Get(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %q: %w", path, err)
+	}
+
+	if !hasField {
+		data, err := json.Marshal(secret.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal vault secret %q: %w", path, err)
+		}
+		return data, nil
+	}
+
+	value, ok := secret.Data[field]
+	if !ok {
+		return nil, fmt.Errorf("vault secret %q has no field %q", path, field)
+	}
+	str, ok := value.(string)
+	if !ok {
+		return nil, fmt.Errorf("vault secret %q field %q is not a string", path, field)
+	}
+	return []byte(str), nil
+}
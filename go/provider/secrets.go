@@ -1,12 +1,21 @@
 package provider
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
 
 	"github.com/nats-io/nkeys"
 )
 
+// maxSecretValueBytes bounds the size of a single secret value (plaintext or, once reassembled,
+// the full chunked payload) accepted from the host, to guard against OOM from a malicious or
+// misbehaving host.
+const maxSecretValueBytes = 1 << 20 // 1 MiB
+
 // Type alias to use for sensitive values to avoid accidentally logging them
 
 type SecretStringValue struct {
@@ -36,11 +45,32 @@ func (s SecretBytesValue) Reveal() []byte {
 type SecretValue struct {
 	String SecretStringValue
 	Bytes  SecretBytesValue
+	// ref is set instead of String/Bytes when this value is a reference into a registered
+	// SecretBackend, resolved lazily by Reveal/RevealBytes.
+	ref *secretRef
+}
+
+// secretRef identifies where a referenced secret lives: backend is the name a SecretBackend was
+// registered under via RegisterSecretBackend, and path is backend-specific (e.g. an SSM parameter
+// name or a Vault KV path).
+type secretRef struct {
+	backend string
+	path    string
 }
 
-// Secret values are serialized as either a String or Bytes value, e.g.
-// {"kind": "String", "value": "my secret"} or {"kind": "Bytes", "value": [1, 2, 3]}
+// Secret values are serialized as a String, Bytes, or Ref value, e.g.
+// {"kind": "String", "value": "my secret"},
+// {"kind": "Bytes", "value": "AQID", "encoding": "base64"}, or
+// {"kind": "Ref", "backend": "aws-ssm", "path": "/prod/db/password"}. A Ref is resolved lazily, the
+// first time Reveal or RevealBytes is called, against the backend registered under that name.
+//
+// Bytes' "value" is always a JSON string (encoding/json never decodes a JSON string into []byte on
+// its own), encoded per its "encoding" field: "base64" (the default, if omitted) or "hex".
 func (s *SecretValue) UnmarshalJSON(data []byte) error {
+	if len(data) > maxSecretValueBytes {
+		return fmt.Errorf("secret value too large: %d bytes exceeds %d byte limit", len(data), maxSecretValueBytes)
+	}
+
 	var jsonSecret map[string]interface{}
 	err := json.Unmarshal(data, &jsonSecret)
 	if err != nil {
@@ -49,16 +79,103 @@ func (s *SecretValue) UnmarshalJSON(data []byte) error {
 
 	switch jsonSecret["kind"] {
 	case "String":
-		s.String = SecretStringValue{value: jsonSecret["value"].(string)}
+		value, ok := jsonSecret["value"].(string)
+		if !ok {
+			return fmt.Errorf("invalid secret: String value must be a string")
+		}
+		s.String = SecretStringValue{value: value}
 	case "Bytes":
-		s.Bytes = SecretBytesValue{value: jsonSecret["value"].([]byte)}
+		value, ok := jsonSecret["value"].(string)
+		if !ok {
+			return fmt.Errorf("invalid secret: Bytes value must be a string")
+		}
+		encoding, _ := jsonSecret["encoding"].(string)
+		decoded, err := decodeSecretBytes(value, encoding)
+		if err != nil {
+			return fmt.Errorf("invalid secret: %w", err)
+		}
+		if len(decoded) > maxSecretValueBytes {
+			return fmt.Errorf("secret value too large: %d bytes exceeds %d byte limit", len(decoded), maxSecretValueBytes)
+		}
+		s.Bytes = SecretBytesValue{value: decoded}
+	case "Ref":
+		backend, ok := jsonSecret["backend"].(string)
+		if !ok {
+			return fmt.Errorf("invalid secret ref: missing backend")
+		}
+		path, ok := jsonSecret["path"].(string)
+		if !ok {
+			return fmt.Errorf("invalid secret ref: missing path")
+		}
+		s.ref = &secretRef{backend: backend, path: path}
 	default:
-		return fmt.Errorf("invalid secret kind: %s", jsonSecret["kind"])
+		return fmt.Errorf("invalid secret kind: %v", jsonSecret["kind"])
 	}
 
 	return nil
 }
 
+// decodeSecretBytes decodes a Bytes secret's wire-format value per encoding ("base64", the
+// default if empty, or "hex").
+func decodeSecretBytes(value, encoding string) ([]byte, error) {
+	switch encoding {
+	case "", "base64":
+		return base64.StdEncoding.DecodeString(value)
+	case "hex":
+		return hex.DecodeString(value)
+	default:
+		return nil, fmt.Errorf("unsupported bytes encoding %q", encoding)
+	}
+}
+
+// MarshalJSON serializes s back to the wire format described on UnmarshalJSON, so providers can
+// round-trip secrets (e.g. when forwarding a resolved Ref as a String/Bytes value). A Ref is
+// marshaled as-is, without resolving it.
+func (s SecretValue) MarshalJSON() ([]byte, error) {
+	if s.ref != nil {
+		return json.Marshal(map[string]string{
+			"kind":    "Ref",
+			"backend": s.ref.backend,
+			"path":    s.ref.path,
+		})
+	}
+	if len(s.Bytes.value) > 0 {
+		return json.Marshal(map[string]string{
+			"kind":     "Bytes",
+			"value":    base64.StdEncoding.EncodeToString(s.Bytes.value),
+			"encoding": "base64",
+		})
+	}
+	return json.Marshal(map[string]string{
+		"kind":  "String",
+		"value": s.String.value,
+	})
+}
+
+// Reveal returns the secret's plaintext string value, resolving it against the registered
+// SecretBackend first if this value is a reference. Resolved references are cached for
+// SecretBackendCacheTTL.
+func (s SecretValue) Reveal() (string, error) {
+	if s.ref == nil {
+		return s.String.Reveal(), nil
+	}
+	b, err := resolveSecretRef(*s.ref)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// RevealBytes returns the secret's plaintext byte value, resolving it against the registered
+// SecretBackend first if this value is a reference. Resolved references are cached for
+// SecretBackendCacheTTL.
+func (s SecretValue) RevealBytes() ([]byte, error) {
+	if s.ref == nil {
+		return s.Bytes.Reveal(), nil
+	}
+	return resolveSecretRef(*s.ref)
+}
+
 func (s *SecretStringValue) UnmarshalJSON(data []byte) error {
 	var stringValue string
 	err := json.Unmarshal(data, &stringValue)
@@ -69,18 +186,107 @@ func (s *SecretStringValue) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SecretChunk is one part of a secrets payload too large to fit in a single NATS message.
+// ReassembleSecretChunks buffers parts sharing a CorrelationID until TotalParts have arrived.
+type SecretChunk struct {
+	CorrelationID string `json:"correlation_id"`
+	Part          int    `json:"part"` // 1-indexed
+	TotalParts    int    `json:"total_parts"`
+	Data          []byte `json:"data"`
+}
+
+var (
+	secretChunksMu sync.Mutex
+	secretChunks   = map[string][][]byte{}
+)
+
+// ReassembleSecretChunks records chunk in the in-progress reassembly buffer for its
+// CorrelationID, returning the concatenated payload and true once every part has arrived, or
+// (nil, false) while parts are still outstanding.
+func ReassembleSecretChunks(chunk SecretChunk) ([]byte, bool, error) {
+	if chunk.TotalParts <= 0 || chunk.Part <= 0 || chunk.Part > chunk.TotalParts {
+		return nil, false, fmt.Errorf("invalid secret chunk %d/%d", chunk.Part, chunk.TotalParts)
+	}
+
+	secretChunksMu.Lock()
+	defer secretChunksMu.Unlock()
+
+	parts, ok := secretChunks[chunk.CorrelationID]
+	if !ok {
+		parts = make([][]byte, chunk.TotalParts)
+	}
+	if chunk.TotalParts != len(parts) {
+		return nil, false, fmt.Errorf("secret chunk %s: total_parts changed from %d to %d mid-reassembly", chunk.CorrelationID, len(parts), chunk.TotalParts)
+	}
+	parts[chunk.Part-1] = chunk.Data
+	secretChunks[chunk.CorrelationID] = parts
+
+	var size int
+	for _, p := range parts {
+		if p == nil {
+			return nil, false, nil
+		}
+		size += len(p)
+	}
+	delete(secretChunks, chunk.CorrelationID)
+
+	if size > maxSecretValueBytes {
+		return nil, false, fmt.Errorf("reassembled secret payload too large: %d bytes exceeds %d byte limit", size, maxSecretValueBytes)
+	}
+
+	reassembled := make([]byte, 0, size)
+	for _, p := range parts {
+		reassembled = append(reassembled, p...)
+	}
+	return reassembled, true, nil
+}
+
+// ErrSecretsIncomplete is returned by DecryptSecrets when encryptedBytes is one part of a
+// chunked secrets payload and not every part has arrived yet. Callers must treat this as "don't
+// apply this link yet, more chunks are coming" rather than "this link has no secrets" — an empty
+// map returned alongside this error is not meaningful and must not be used.
+var ErrSecretsIncomplete = errors.New("secrets payload incomplete: awaiting more chunks")
+
+// DecryptSecrets decrypts and unmarshals the XKey-encrypted secrets blob sent for a link.
+// encryptedBytes is ordinarily the whole encrypted payload in one piece, but when a secrets
+// payload is too large for a single NATS message, it instead contains a JSON-encoded SecretChunk:
+// DecryptSecrets detects this and reassembles all chunks sharing that chunk's CorrelationID (via
+// ReassembleSecretChunks) before decrypting, returning ErrSecretsIncomplete until every part has
+// arrived.
 func DecryptSecrets(encryptedBytes *[]byte, xkey nkeys.KeyPair, sender string) (map[string]SecretValue, error) {
 	var sourceSecrets = make(map[string]SecretValue)
 	// If the source secrets are empty or not present, we don't need to decrypt/unmarshal them
-	if encryptedBytes != nil && len(*encryptedBytes) >= 0 {
-		sourceSecretBytes, err := xkey.Open(*encryptedBytes, sender)
+	if encryptedBytes == nil || len(*encryptedBytes) == 0 {
+		return sourceSecrets, nil
+	}
+
+	payload := *encryptedBytes
+	var chunk SecretChunk
+	if json.Unmarshal(payload, &chunk) == nil && chunk.TotalParts > 0 {
+		reassembled, complete, err := ReassembleSecretChunks(chunk)
 		if err != nil {
-			return sourceSecrets, err
+			return nil, fmt.Errorf("failed to reassemble chunked secret payload: %w", err)
 		}
-		err = json.Unmarshal(sourceSecretBytes, &sourceSecrets)
-		if err != nil {
-			return sourceSecrets, err
+		if !complete {
+			return nil, ErrSecretsIncomplete
 		}
+		payload = reassembled
+	}
+
+	if len(payload) > maxSecretValueBytes {
+		return nil, fmt.Errorf("encrypted secret payload too large: %d bytes exceeds %d byte limit", len(payload), maxSecretValueBytes)
+	}
+
+	sourceSecretBytes, err := xkey.Open(payload, sender)
+	if err != nil {
+		return nil, err
+	}
+	if len(sourceSecretBytes) > maxSecretValueBytes {
+		return nil, fmt.Errorf("decrypted secret payload too large: %d bytes exceeds %d byte limit", len(sourceSecretBytes), maxSecretValueBytes)
+	}
+	err = json.Unmarshal(sourceSecretBytes, &sourceSecrets)
+	if err != nil {
+		return nil, err
 	}
 	return sourceSecrets, nil
 }
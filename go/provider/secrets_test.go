@@ -2,7 +2,10 @@ package provider
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
+
+	"github.com/nats-io/nkeys"
 )
 
 func TestUnmarshalJson(t *testing.T) {
@@ -25,6 +28,138 @@ func TestUnmarshalJson(t *testing.T) {
 	}
 }
 
+func TestUnmarshalJsonBytesBase64(t *testing.T) {
+	jsonData := `{"kind": "Bytes", "value": "AQIDBA==", "encoding": "base64"}`
+
+	secret := &SecretValue{}
+	if err := json.Unmarshal([]byte(jsonData), secret); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	expected := []byte{1, 2, 3, 4}
+	if string(secret.Bytes.Reveal()) != string(expected) {
+		t.Errorf("Unexpected value. Got: %v, Expected: %v", secret.Bytes.Reveal(), expected)
+	}
+}
+
+func TestUnmarshalJsonBytesHex(t *testing.T) {
+	jsonData := `{"kind": "Bytes", "value": "01020304", "encoding": "hex"}`
+
+	secret := &SecretValue{}
+	if err := json.Unmarshal([]byte(jsonData), secret); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	expected := []byte{1, 2, 3, 4}
+	if string(secret.Bytes.Reveal()) != string(expected) {
+		t.Errorf("Unexpected value. Got: %v, Expected: %v", secret.Bytes.Reveal(), expected)
+	}
+}
+
+func TestMarshalJsonRoundTrip(t *testing.T) {
+	secret := &SecretValue{}
+	if err := json.Unmarshal([]byte(`{"kind": "Bytes", "value": "AQIDBA==", "encoding": "base64"}`), secret); err != nil {
+		t.Fatalf("Failed to unmarshal JSON: %v", err)
+	}
+
+	data, err := json.Marshal(secret)
+	if err != nil {
+		t.Fatalf("Failed to marshal JSON: %v", err)
+	}
+
+	roundTripped := &SecretValue{}
+	if err := json.Unmarshal(data, roundTripped); err != nil {
+		t.Fatalf("Failed to unmarshal round-tripped JSON: %v", err)
+	}
+	if string(roundTripped.Bytes.Reveal()) != string(secret.Bytes.Reveal()) {
+		t.Errorf("Round trip mismatch. Got: %v, Expected: %v", roundTripped.Bytes.Reveal(), secret.Bytes.Reveal())
+	}
+}
+
+func FuzzSecretValueUnmarshalJSON(f *testing.F) {
+	f.Add([]byte(`{"kind": "String", "value": "mySecretValue"}`))
+	f.Add([]byte(`{"kind": "Bytes", "value": "AQIDBA==", "encoding": "base64"}`))
+	f.Add([]byte(`{"kind": "Bytes", "value": "01020304", "encoding": "hex"}`))
+	f.Add([]byte(`{"kind": "Ref", "backend": "ssm", "path": "/prod/db/password"}`))
+	f.Add([]byte(`{}`))
+	f.Add([]byte(`not json`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var secret SecretValue
+		// UnmarshalJSON must never panic, regardless of what a host sends; an error return is
+		// fine.
+		_ = json.Unmarshal(data, &secret)
+	})
+}
+
+// TestDecryptSecretsChunkedPayload exercises the chunked reassembly path end-to-end: a sealed
+// payload is split across two SecretChunk messages, and DecryptSecrets must report
+// ErrSecretsIncomplete (not an empty map mistaken for "no secrets") until the last one arrives.
+func TestDecryptSecretsChunkedPayload(t *testing.T) {
+	recipient, err := nkeys.CreateCurveKeys()
+	if err != nil {
+		t.Fatalf("failed to create recipient xkey: %v", err)
+	}
+	recipientPub, err := recipient.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to get recipient public key: %v", err)
+	}
+
+	sender, err := nkeys.CreateCurveKeys()
+	if err != nil {
+		t.Fatalf("failed to create sender xkey: %v", err)
+	}
+	senderPub, err := sender.PublicKey()
+	if err != nil {
+		t.Fatalf("failed to get sender public key: %v", err)
+	}
+
+	plaintext, err := json.Marshal(map[string]SecretValue{
+		"password": {String: SecretStringValue{value: "hunter2"}},
+	})
+	if err != nil {
+		t.Fatalf("failed to marshal secrets: %v", err)
+	}
+	sealed, err := sender.Seal(plaintext, recipientPub)
+	if err != nil {
+		t.Fatalf("failed to seal secrets: %v", err)
+	}
+
+	mid := len(sealed) / 2
+	parts := [][]byte{sealed[:mid], sealed[mid:]}
+
+	for i, data := range parts {
+		chunk := SecretChunk{
+			CorrelationID: "test-correlation-id",
+			Part:          i + 1,
+			TotalParts:    len(parts),
+			Data:          data,
+		}
+		raw, err := json.Marshal(chunk)
+		if err != nil {
+			t.Fatalf("failed to marshal chunk %d: %v", i+1, err)
+		}
+
+		secrets, err := DecryptSecrets(&raw, recipient, senderPub)
+		if i < len(parts)-1 {
+			if !errors.Is(err, ErrSecretsIncomplete) {
+				t.Fatalf("part %d/%d: expected ErrSecretsIncomplete, got secrets=%v err=%v", i+1, len(parts), secrets, err)
+			}
+			if secrets != nil {
+				t.Fatalf("part %d/%d: expected a nil map while incomplete, got %v", i+1, len(parts), secrets)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("final part: unexpected error: %v", err)
+		}
+		if secrets["password"].String.Reveal() != "hunter2" {
+			t.Fatalf("unexpected secrets after reassembly: %v", secrets)
+		}
+	}
+}
+
 func TestUnmarshalJsonMap(t *testing.T) {
 	// Define the JSON input
 	jsonData := `{"foobar": {"kind": "String", "value": "mySecretValue"}}`
@@ -0,0 +1,158 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// defaultShutdownTimeout bounds Shutdown's overall deadline when the context it's called with has
+// no deadline of its own and WithShutdownTimeout wasn't used to set one.
+const defaultShutdownTimeout = 30 * time.Second
+
+// ShutdownPhase identifies one ordered step of WasmcloudProvider.Shutdown's graceful shutdown
+// sequence. Register additional work at a phase with WithShutdownHook.
+type ShutdownPhase int
+
+const (
+	// ShutdownPhaseUnsubscribeLinkPut stops the transport from accepting new link puts first, so
+	// no new link triggers more work while the rest of shutdown is in progress.
+	ShutdownPhaseUnsubscribeLinkPut ShutdownPhase = iota
+	// ShutdownPhaseUserFunc runs the user-provided shutdownFunc (see the Shutdown option).
+	ShutdownPhaseUserFunc
+	// ShutdownPhaseDrainRPC waits for in-flight RPCs on RPCClient (tracked via StartRPCSpan) to
+	// finish, up to the remaining deadline.
+	ShutdownPhaseDrainRPC
+	// ShutdownPhaseDrainSubscriptions closes the transport (draining its remaining NATS
+	// subscriptions) and the cluster, if configured.
+	ShutdownPhaseDrainSubscriptions
+	// ShutdownPhaseInternal runs internalShutdownFuncs (OTel exporters, log sinks, the Prometheus
+	// metrics server) with whatever budget remains.
+	ShutdownPhaseInternal
+)
+
+func (p ShutdownPhase) String() string {
+	switch p {
+	case ShutdownPhaseUnsubscribeLinkPut:
+		return "unsubscribe_link_put"
+	case ShutdownPhaseUserFunc:
+		return "user_func"
+	case ShutdownPhaseDrainRPC:
+		return "drain_rpc"
+	case ShutdownPhaseDrainSubscriptions:
+		return "drain_subscriptions"
+	case ShutdownPhaseInternal:
+		return "internal"
+	default:
+		return fmt.Sprintf("ShutdownPhase(%d)", int(p))
+	}
+}
+
+// WithShutdownTimeout overrides defaultShutdownTimeout as the deadline Shutdown gives itself when
+// called with a context that has no deadline of its own.
+func WithShutdownTimeout(d time.Duration) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.shutdownTimeout = d
+		return nil
+	}
+}
+
+// WithShutdownHook registers fn to run during phase, in addition to (after) whatever built-in
+// work that phase already does. Hooks for the same phase run in the order they're registered; a
+// hook's error is aggregated into Shutdown's returned error rather than skipping later phases.
+func WithShutdownHook(phase ShutdownPhase, fn func(context.Context) error) ProviderHandler {
+	return func(wp *WasmcloudProvider) error {
+		wp.shutdownHooks[phase] = append(wp.shutdownHooks[phase], fn)
+		return nil
+	}
+}
+
+// Shutdown runs the provider's graceful shutdown sequence: unsubscribe from LATTICE_LINK_PUT,
+// run the user's shutdownFunc, wait for in-flight RPCs to drain, close the transport (and
+// cluster, if any), run internalShutdownFuncs, then cancel the provider's context. Each phase
+// runs even if an earlier one failed, and every phase's errors (including WithShutdownHook
+// failures) are aggregated into the returned error instead of the first one short-circuiting the
+// rest. If ctx has no deadline, one is added from wp.shutdownTimeout (or defaultShutdownTimeout).
+func (wp *WasmcloudProvider) Shutdown(ctx context.Context) error {
+	if _, ok := ctx.Deadline(); !ok {
+		timeout := wp.shutdownTimeout
+		if timeout <= 0 {
+			timeout = defaultShutdownTimeout
+		}
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var errs []error
+	collect := func(err error) {
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Phase 1: stop new link-triggered work from starting mid-shutdown. Routed through
+	// wp.transport so this stops link puts regardless of whether they're arriving over NATS or
+	// (with GrpcTransport) gRPC.
+	collect(wp.transport.StopLinkPuts(wp))
+	collect(wp.runShutdownHooks(ctx, ShutdownPhaseUnsubscribeLinkPut))
+
+	// Phase 2: run the user's shutdownFunc.
+	collect(wp.shutdownFunc(ctx))
+	collect(wp.runShutdownHooks(ctx, ShutdownPhaseUserFunc))
+
+	// Phase 3: wait for in-flight RPCs to drain, bounded by ctx's (possibly just-added) deadline.
+	collect(wp.waitForRPCDrain(ctx))
+	collect(wp.runShutdownHooks(ctx, ShutdownPhaseDrainRPC))
+
+	// Phase 4: close the transport (draining its remaining subscriptions) and the cluster.
+	collect(wp.transport.Close(wp))
+	if wp.cluster != nil {
+		collect(wp.cluster.Close())
+	}
+	collect(wp.runShutdownHooks(ctx, ShutdownPhaseDrainSubscriptions))
+
+	// Phase 5: run internalShutdownFuncs (OTel exporters, log sinks, metrics server) with
+	// whatever budget remains on ctx.
+	for _, errFunc := range wp.internalShutdownFuncs {
+		collect(errFunc(ctx))
+	}
+	collect(wp.runShutdownHooks(ctx, ShutdownPhaseInternal))
+
+	// Phase 6: cancel the provider's context, unblocking Start.
+	wp.cancel()
+
+	return errors.Join(errs...)
+}
+
+// runShutdownHooks runs every WithShutdownHook registered for phase, in registration order,
+// logging (but not stopping on) each one's error and returning them joined.
+func (wp *WasmcloudProvider) runShutdownHooks(ctx context.Context, phase ShutdownPhase) error {
+	var errs []error
+	for _, hook := range wp.shutdownHooks[phase] {
+		if err := hook(ctx); err != nil {
+			wp.Logger.Error("shutdown hook failed", "phase", phase.String(), slog.Any("error", err))
+			errs = append(errs, fmt.Errorf("shutdown hook (%s): %w", phase, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// waitForRPCDrain waits for wp.inflightRPCs (every StartRPCSpan call not yet matched by its
+// span's End) to reach zero, or ctx to be done, whichever comes first.
+func (wp *WasmcloudProvider) waitForRPCDrain(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		wp.inflightRPCs.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for in-flight RPCs to drain: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,146 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	nats "github.com/nats-io/nats.go"
+)
+
+type fakeShutdownTransport struct {
+	closed bool
+}
+
+func (t *fakeShutdownTransport) Subscribe(*WasmcloudProvider) error { return nil }
+
+func (t *fakeShutdownTransport) StopLinkPuts(*WasmcloudProvider) error { return nil }
+
+func (t *fakeShutdownTransport) Close(*WasmcloudProvider) error {
+	t.closed = true
+	return nil
+}
+
+func newTestProviderForShutdown() *WasmcloudProvider {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &WasmcloudProvider{
+		Logger:            slog.New(slog.NewTextHandler(io.Discard, nil)),
+		context:           ctx,
+		cancel:            cancel,
+		transport:         &fakeShutdownTransport{},
+		shutdownFunc:      func(context.Context) error { return nil },
+		natsSubscriptions: map[string]*nats.Subscription{},
+		shutdownHooks:     make(map[ShutdownPhase][]func(context.Context) error),
+	}
+}
+
+func TestShutdownRunsUserFuncAndClosesTransport(t *testing.T) {
+	wp := newTestProviderForShutdown()
+	transport := wp.transport.(*fakeShutdownTransport)
+	var userFuncRan bool
+	wp.shutdownFunc = func(context.Context) error {
+		userFuncRan = true
+		return nil
+	}
+
+	if err := wp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if !userFuncRan {
+		t.Error("expected shutdownFunc to run")
+	}
+	if !transport.closed {
+		t.Error("expected transport.Close to run")
+	}
+	select {
+	case <-wp.context.Done():
+	default:
+		t.Error("expected Shutdown to cancel the provider's context")
+	}
+}
+
+func TestShutdownAggregatesErrorsAcrossPhases(t *testing.T) {
+	wp := newTestProviderForShutdown()
+	wp.shutdownFunc = func(context.Context) error { return errors.New("user func failed") }
+	wp.internalShutdownFuncs = []func(context.Context) error{
+		func(context.Context) error { return errors.New("otel shutdown failed") },
+	}
+
+	err := wp.Shutdown(context.Background())
+	if err == nil {
+		t.Fatal("expected an aggregated error")
+	}
+	if !errors.As(err, new(interface{ Unwrap() []error })) {
+		t.Fatalf("expected an errors.Join error, got %T", err)
+	}
+}
+
+func TestShutdownRunsHooksInRegistrationOrder(t *testing.T) {
+	wp := newTestProviderForShutdown()
+	var order []string
+	WithShutdownHook(ShutdownPhaseUserFunc, func(context.Context) error {
+		order = append(order, "first")
+		return nil
+	})(wp)
+	WithShutdownHook(ShutdownPhaseUserFunc, func(context.Context) error {
+		order = append(order, "second")
+		return nil
+	})(wp)
+
+	if err := wp.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown returned error: %v", err)
+	}
+	if len(order) != 2 || order[0] != "first" || order[1] != "second" {
+		t.Fatalf("got hook order %v, want [first second]", order)
+	}
+}
+
+func TestWaitForRPCDrainWaitsForInflightRPCsToFinish(t *testing.T) {
+	wp := newTestProviderForShutdown()
+	wp.inflightRPCs.Add(1)
+
+	done := make(chan error, 1)
+	go func() { done <- wp.waitForRPCDrain(context.Background()) }()
+
+	select {
+	case <-done:
+		t.Fatal("expected waitForRPCDrain to block while an RPC is in flight")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	wp.inflightRPCs.Done()
+	if err := <-done; err != nil {
+		t.Fatalf("waitForRPCDrain returned error: %v", err)
+	}
+}
+
+func TestWaitForRPCDrainReturnsErrorOnContextDeadline(t *testing.T) {
+	wp := newTestProviderForShutdown()
+	wp.inflightRPCs.Add(1)
+	defer wp.inflightRPCs.Done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	if err := wp.waitForRPCDrain(ctx); err == nil {
+		t.Error("expected an error when the context deadline passes before RPCs drain")
+	}
+}
+
+func TestShutdownPhaseString(t *testing.T) {
+	tests := map[ShutdownPhase]string{
+		ShutdownPhaseUnsubscribeLinkPut: "unsubscribe_link_put",
+		ShutdownPhaseUserFunc:           "user_func",
+		ShutdownPhaseDrainRPC:           "drain_rpc",
+		ShutdownPhaseDrainSubscriptions: "drain_subscriptions",
+		ShutdownPhaseInternal:           "internal",
+	}
+	for phase, want := range tests {
+		if got := phase.String(); got != want {
+			t.Errorf("ShutdownPhase(%d).String() = %q, want %q", phase, got, want)
+		}
+	}
+}
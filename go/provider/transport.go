@@ -0,0 +1,72 @@
+package provider
+
+// Transport abstracts the lattice control-plane channel a WasmcloudProvider listens on for
+// health checks, link put/del, link health, config updates, shutdown, lifecycle events, and OTel
+// reconfiguration. NatsTransport (the default) is current behavior, subscribing to wp.Topics on
+// wp.natsConnection; GrpcTransport serves health checks, link put/del, link health, config
+// updates, and shutdown on a gRPC listener instead, and does not receive lifecycle events or OTel
+// reconfiguration. Select one via HostData.RPCTransport, or override it directly with WithTransport.
+//
+// Transport does not make a provider NATS-free: wp.RPCClient, the *wrpcnats.Client wit-generated
+// bindings pass to server.Serve to dispatch wit interface calls, always dispatches over NATS,
+// since wit-bindgen-wrpc only generates NATS-backed bindings today. Changing Transport changes
+// how control-plane messages move, not how RPC invocations are dispatched — a provider configured
+// with GrpcTransport still needs a NATS connection for wit RPC.
+type Transport interface {
+	// Subscribe starts listening for control-plane messages and dispatching them to wp's
+	// registered handlers (putSourceLinkFunc, healthMsgFunc, shutdownFunc, and so on).
+	Subscribe(wp *WasmcloudProvider) error
+	// StopLinkPuts stops accepting new link put requests ahead of the rest of Shutdown, so no
+	// link enrolls while later shutdown phases are tearing down. Called from Shutdown's first
+	// phase, before Close drains whatever Subscribe started.
+	StopLinkPuts(wp *WasmcloudProvider) error
+	// Close tears down everything Subscribe started.
+	Close(wp *WasmcloudProvider) error
+}
+
+// NatsTransport is the default Transport, subscribing to wp.Topics on wp.natsConnection exactly
+// as providers have always done. Its zero value is ready to use.
+type NatsTransport struct{}
+
+func (NatsTransport) Subscribe(wp *WasmcloudProvider) error {
+	return wp.subToNats()
+}
+
+func (NatsTransport) StopLinkPuts(wp *WasmcloudProvider) error {
+	sub, ok := wp.natsSubscriptions[wp.Topics.LATTICE_LINK_PUT]
+	if !ok {
+		return nil
+	}
+	delete(wp.natsSubscriptions, wp.Topics.LATTICE_LINK_PUT)
+	return sub.Drain()
+}
+
+func (NatsTransport) Close(wp *WasmcloudProvider) error {
+	return wp.cleanupNatsSubscriptions()
+}
+
+// transportFromHostData picks the Transport New configures by default, based on
+// HostData.RPCTransport ("nats", the default when empty, or "grpc"). WithTransport overrides
+// whatever this returns.
+func transportFromHostData(hostData HostData) (Transport, error) {
+	switch hostData.RPCTransport {
+	case "", "nats":
+		return NatsTransport{}, nil
+	case "grpc":
+		addr := hostData.GRPCListenAddr
+		if addr == "" {
+			addr = ":8443"
+		}
+		return &GrpcTransport{Addr: addr}, nil
+	default:
+		return nil, &unknownTransportError{hostData.RPCTransport}
+	}
+}
+
+type unknownTransportError struct {
+	name string
+}
+
+func (e *unknownTransportError) Error() string {
+	return "unknown rpc_transport " + e.name + ": expected \"nats\" or \"grpc\""
+}
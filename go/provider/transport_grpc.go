@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func init() {
+	encoding.RegisterCodec(controlPlaneJSONCodec{})
+}
+
+// controlPlaneJSONCodec lets GrpcTransport's service carry the same JSON bodies NatsTransport
+// already sends over NATS subjects (HealthCheckResponse, InterfaceLinkDefinition, and so on),
+// instead of requiring a .proto schema and generated message types this repo doesn't otherwise
+// have. Clients must dial with grpc.CallContentSubtype("json") for this to take effect.
+type controlPlaneJSONCodec struct{}
+
+func (controlPlaneJSONCodec) Marshal(v interface{}) ([]byte, error) { return json.Marshal(v) }
+func (controlPlaneJSONCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+func (controlPlaneJSONCodec) Name() string { return "json" }
+
+// GrpcTransport is a Transport alternative to NatsTransport that exposes the lattice control
+// plane as a gRPC service, "wasmcloud.provider.v1.ControlPlane" (see controlPlaneServiceDesc),
+// reusing controlPlaneJSONCodec rather than protobuf so its wire shapes match NatsTransport's
+// exactly. Link put/del handlers publish onto the provider's EventBus exactly as subToNats does,
+// so Events().Subscribe subscribers fire the same way regardless of which Transport received the
+// message, and every method is gated by requireRPCAuth, the gRPC equivalent of JWTAuth.Wrap.
+//
+// GrpcTransport does not make wp.RPCClient NATS-free: wit interface calls still dispatch over
+// NATS via wrpcnats, since wit-bindgen-wrpc doesn't generate gRPC bindings (see the Transport doc
+// comment). GrpcTransport only replaces the control-plane channel NatsTransport otherwise
+// subscribes wp.Topics on.
+type GrpcTransport struct {
+	// Addr is the address the gRPC server listens on, e.g. ":8443".
+	Addr string
+
+	mu              sync.Mutex
+	server          *grpc.Server
+	linkPutsStopped atomic.Bool
+}
+
+func (t *GrpcTransport) Subscribe(wp *WasmcloudProvider) error {
+	lis, err := net.Listen("tcp", t.Addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %q: %w", t.Addr, err)
+	}
+
+	server := grpc.NewServer()
+	server.RegisterService(&controlPlaneServiceDesc, wp)
+
+	t.mu.Lock()
+	t.server = server
+	t.mu.Unlock()
+
+	go func() {
+		if err := server.Serve(lis); err != nil {
+			wp.Logger.Error("grpc control-plane server stopped", slog.Any("error", err))
+		}
+	}()
+
+	wp.Logger.Info("grpc control-plane listening", "addr", t.Addr)
+	return nil
+}
+
+func (t *GrpcTransport) StopLinkPuts(_ *WasmcloudProvider) error {
+	t.linkPutsStopped.Store(true)
+	return nil
+}
+
+func (t *GrpcTransport) Close(_ *WasmcloudProvider) error {
+	t.mu.Lock()
+	server := t.server
+	t.mu.Unlock()
+
+	if server != nil {
+		server.GracefulStop()
+	}
+	return nil
+}
+
+// controlPlaneServiceDesc describes "wasmcloud.provider.v1.ControlPlane" for grpc.Server's
+// RegisterService: the same operations NatsTransport dispatches from LATTICE_HEALTH,
+// LATTICE_LINK_PUT, LATTICE_LINK_DEL, LATTICE_LINK_HEALTH, LATTICE_CONFIG_UPDATE, and
+// LATTICE_SHUTDOWN, as unary RPCs instead of NATS request/reply. HandlerType is unused since the
+// handlers below type-assert srv to *WasmcloudProvider themselves rather than going through a
+// generated interface. Every handler is wrapped with requireRPCAuth, mirroring wp.jwtAuth.Wrap
+// around each of subToNats's NATS subscriptions.
+var controlPlaneServiceDesc = grpc.ServiceDesc{
+	ServiceName: "wasmcloud.provider.v1.ControlPlane",
+	HandlerType: (*any)(nil),
+	Methods: []grpc.MethodDesc{
+		{MethodName: "HealthCheck", Handler: requireRPCAuth(controlPlaneHealthCheckHandler)},
+		{MethodName: "LinkPut", Handler: requireRPCAuth(controlPlaneLinkPutHandler)},
+		{MethodName: "LinkDel", Handler: requireRPCAuth(controlPlaneLinkDelHandler)},
+		{MethodName: "LinkHealth", Handler: requireRPCAuth(controlPlaneLinkHealthHandler)},
+		{MethodName: "ConfigUpdate", Handler: requireRPCAuth(controlPlaneConfigUpdateHandler)},
+		{MethodName: "Shutdown", Handler: requireRPCAuth(controlPlaneShutdownHandler)},
+	},
+}
+
+// requireRPCAuth wraps handler so a request is rejected before reaching it unless wp.jwtAuth
+// authenticates a token carried in the RPCAuthHeader gRPC metadata key — the gRPC equivalent of
+// JWTAuth.Wrap for NATS subscriptions (see subToNats). A nil wp.jwtAuth (RPC auth disabled) is a
+// no-op, matching JWTAuth.Wrap's nil-safety.
+func requireRPCAuth(handler grpc.MethodHandler) grpc.MethodHandler {
+	return func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+		wp := srv.(*WasmcloudProvider)
+		if wp.jwtAuth != nil {
+			var token string
+			if md, ok := metadata.FromIncomingContext(ctx); ok {
+				if vals := md.Get(RPCAuthHeader); len(vals) > 0 {
+					token = vals[0]
+				}
+			}
+			if err := wp.jwtAuth.authenticateToken(token); err != nil {
+				wp.Logger.Warn("rejected unauthenticated RPC message", "transport", "grpc", slog.Any("error", err))
+				return nil, status.Error(codes.Unauthenticated, err.Error())
+			}
+		}
+		return handler(srv, ctx, dec, interceptor)
+	}
+}
+
+func controlPlaneHealthCheckHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+	wp := srv.(*WasmcloudProvider)
+	if !wp.checkPolicy(ctx, "health", map[string]interface{}{"providerId": wp.Id}) {
+		return nil, fmt.Errorf("health check denied by policy")
+	}
+	hc := HealthCheckResponse{Healthy: true, Message: wp.healthMsgFunc(ctx)}
+	wp.recordHealthcheckStatus(hc.Healthy)
+	return &hc, nil
+}
+
+func controlPlaneLinkPutHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+	wp := srv.(*WasmcloudProvider)
+	if gt, ok := wp.transport.(*GrpcTransport); ok && gt.linkPutsStopped.Load() {
+		return nil, status.Error(codes.Unavailable, "provider is shutting down, not accepting new links")
+	}
+	var link InterfaceLinkDefinition
+	if err := dec(&link); err != nil {
+		return nil, err
+	}
+	if !wp.checkPolicy(ctx, "link_put", linkPolicyInput(link)) {
+		return nil, fmt.Errorf("link put denied by policy")
+	}
+	// Publish rather than calling wp.putLink directly so Events().Subscribe subscribers see links
+	// received over gRPC exactly as they do over NATS (see subToNats's LATTICE_LINK_PUT handler);
+	// EventLinkPut's default subscriber, registered by registerDefaultEventSubscribers, is
+	// wp.putLink itself.
+	wp.events.Publish(ctx, LinkPutEvent{Link: link})
+	return &emptyControlPlaneResponse{}, nil
+}
+
+func controlPlaneLinkDelHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+	wp := srv.(*WasmcloudProvider)
+	var link InterfaceLinkDefinition
+	if err := dec(&link); err != nil {
+		return nil, err
+	}
+	if !wp.checkPolicy(ctx, "link_del", linkPolicyInput(link)) {
+		return nil, fmt.Errorf("link del denied by policy")
+	}
+	// Publish rather than calling wp.deleteLink directly, for the same reason as
+	// controlPlaneLinkPutHandler above.
+	wp.events.Publish(ctx, LinkDelEvent{Link: link})
+	return &emptyControlPlaneResponse{}, nil
+}
+
+func controlPlaneLinkHealthHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+	wp := srv.(*WasmcloudProvider)
+	var link InterfaceLinkDefinition
+	if err := dec(&link); err != nil {
+		return nil, err
+	}
+	if !wp.checkPolicy(ctx, "link_health", linkPolicyInput(link)) {
+		return nil, fmt.Errorf("link health denied by policy")
+	}
+	health := wp.linkHealthFunc(ctx, link)
+	return &health, nil
+}
+
+func controlPlaneConfigUpdateHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+	wp := srv.(*WasmcloudProvider)
+	var config map[string]string
+	if err := dec(&config); err != nil {
+		return nil, err
+	}
+	if err := wp.configUpdateFunc(ctx, config); err != nil {
+		return nil, err
+	}
+	return &emptyControlPlaneResponse{}, nil
+}
+
+func controlPlaneShutdownHandler(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+	wp := srv.(*WasmcloudProvider)
+	if err := wp.shutdownFunc(ctx); err != nil {
+		return nil, err
+	}
+	wp.cancel()
+	return &emptyControlPlaneResponse{}, nil
+}
+
+// emptyControlPlaneResponse is the JSON body ("{}") for control-plane RPCs that don't otherwise
+// return a value.
+type emptyControlPlaneResponse struct{}
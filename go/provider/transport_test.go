@@ -0,0 +1,44 @@
+package provider
+
+import "testing"
+
+func TestTransportFromHostDataDefaultsToNats(t *testing.T) {
+	transport, err := transportFromHostData(HostData{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := transport.(NatsTransport); !ok {
+		t.Errorf("expected NatsTransport for an unset RPCTransport, got %T", transport)
+	}
+}
+
+func TestTransportFromHostDataGrpcDefaultsAddr(t *testing.T) {
+	transport, err := transportFromHostData(HostData{RPCTransport: "grpc"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	grpcTransport, ok := transport.(*GrpcTransport)
+	if !ok {
+		t.Fatalf("expected *GrpcTransport, got %T", transport)
+	}
+	if grpcTransport.Addr != ":8443" {
+		t.Errorf("expected default addr :8443, got %q", grpcTransport.Addr)
+	}
+}
+
+func TestTransportFromHostDataGrpcHonorsListenAddr(t *testing.T) {
+	transport, err := transportFromHostData(HostData{RPCTransport: "grpc", GRPCListenAddr: ":9000"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	grpcTransport := transport.(*GrpcTransport)
+	if grpcTransport.Addr != ":9000" {
+		t.Errorf("expected addr :9000, got %q", grpcTransport.Addr)
+	}
+}
+
+func TestTransportFromHostDataRejectsUnknownTransport(t *testing.T) {
+	if _, err := transportFromHostData(HostData{RPCTransport: "carrier-pigeon"}); err == nil {
+		t.Error("expected an error for an unknown rpc_transport")
+	}
+}